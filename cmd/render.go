@@ -1,5 +1,212 @@
+// Command render renders a YAML scene file to an image.
+//
+// Usage:
+//
+//	render [flags] scene.yml output-file
+//
+// The output format is taken from the extension of the output file unless the -format flag is given.
+// The .ppm files are written in the binary P6 format, the ppm-ascii format writes the plain P3 format.
+// The .png files have 8 bits per channel, the png16 format writes 16 bits per channel.
+// The .pfm and .hdr files keep colors brighter than 1.0 for grading and compositing.
 package main
 
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/tyz910/ray-tracer-challenge/internal/canvas"
+	"github.com/tyz910/ray-tracer-challenge/internal/canvas/image"
+	"github.com/tyz910/ray-tracer-challenge/internal/render/camera"
+	"github.com/tyz910/ray-tracer-challenge/internal/render/scene"
+)
+
+// Exit codes of the command.
+const (
+	exitOK          = 0
+	exitUsage       = 2
+	exitParseError  = 3
+	exitRenderError = 4
+)
+
+// formats maps the names of the supported output formats to the functions saving the canvas.
+var formats = map[string]func(cnv canvas.Canvas, filename string) error{
+	"ppm": func(cnv canvas.Canvas, filename string) error {
+		ppm := image.NewPPM(cnv)
+		ppm.SetFormat(image.P6)
+
+		return ppm.Save(filename)
+	},
+	"ppm-ascii": func(cnv canvas.Canvas, filename string) error {
+		return image.NewPPM(cnv).Save(filename)
+	},
+	"png": func(cnv canvas.Canvas, filename string) error {
+		return image.NewPNG(cnv).Save(filename)
+	},
+	"png16": func(cnv canvas.Canvas, filename string) error {
+		png := image.NewPNG(cnv)
+		png.SetDepth(image.Depth16)
+
+		return png.Save(filename)
+	},
+	"pfm": func(cnv canvas.Canvas, filename string) error {
+		return image.NewPFM(cnv).Save(filename)
+	},
+	"hdr": func(cnv canvas.Canvas, filename string) error {
+		return image.NewHDR(cnv).Save(filename)
+	},
+}
+
 func main() {
+	os.Exit(run(os.Args[1:], os.Stderr))
+}
+
+func run(args []string, stderr io.Writer) int {
+	flags := flag.NewFlagSet("render", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.Usage = func() {
+		fmt.Fprint(stderr, "Usage: render [flags] scene.yml output-file\n\nFlags:\n")
+		flags.PrintDefaults()
+	}
+
+	width := flags.Int("width", 0, "override the image width in pixels; the height follows the aspect ratio unless also given")
+	height := flags.Int("height", 0, "override the image height in pixels; the width follows the aspect ratio unless also given")
+	format := flags.String("format", "", "output format: "+strings.Join(formatNames(), ", ")+" (default: the extension of the output file)")
+	workers := flags.Int("workers", 0, "number of goroutines rendering in parallel (default: the number of CPUs)")
+	samples := flags.Int("samples", 1, "number of rays cast through every pixel")
+	progress := flags.Bool("progress", false, "show a progress bar on stderr")
+
+	if err := flags.Parse(args); err != nil {
+		return exitUsage
+	}
+
+	if flags.NArg() != 2 {
+		flags.Usage()
+		return exitUsage
+	}
+
+	sceneFile, outputFile := flags.Arg(0), flags.Arg(1)
+
+	if *format == "" {
+		*format = strings.TrimPrefix(strings.ToLower(filepath.Ext(outputFile)), ".")
+	}
+
+	save, ok := formats[*format]
+	if !ok {
+		fmt.Fprintf(stderr, "render: unsupported output format %q\n", *format)
+		return exitUsage
+	}
+
+	if *width < 0 || *height < 0 {
+		fmt.Fprintln(stderr, "render: image size must be positive")
+		return exitUsage
+	}
+
+	s, err := loadScene(sceneFile)
+	if err != nil {
+		fmt.Fprintf(stderr, "render: %s: %v\n", sceneFile, err)
+		return exitParseError
+	}
+
+	for _, warning := range s.Warnings() {
+		fmt.Fprintf(stderr, "render: %s: warning: %s\n", sceneFile, warning)
+	}
+
+	c := resize(s.Camera(), *width, *height)
+	if *workers > 0 {
+		c.SetWorkers(*workers)
+	}
+	c.SetSamples(*samples)
+
+	if *progress {
+		bar := &progressBar{out: stderr, width: 40}
+		c.SetProgress(bar.Update)
+	}
+
+	cnv, err := c.Render(s.World())
+	if err != nil {
+		fmt.Fprintf(stderr, "render: %v\n", err)
+		return exitRenderError
+	}
+
+	if err := save(cnv, outputFile); err != nil {
+		fmt.Fprintf(stderr, "render: %v\n", err)
+		return exitRenderError
+	}
+
+	return exitOK
+}
+
+// loadScene loads the scene file, converting a panic of the loader to an error,
+// so that a malformed scene is reported as a parse error instead of crashing the command.
+func loadScene(filename string) (s *scene.Scene, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("loading failed: %v", r)
+		}
+	}()
+
+	return scene.LoadFile(filename)
+}
+
+// resize returns the camera with the given image size, keeping the field of view and the transformation.
+// Zero width or height is computed from the other one keeping the aspect ratio of the camera.
+func resize(c *camera.Camera, width, height int) *camera.Camera {
+	if width == 0 && height == 0 {
+		return c
+	}
+
+	if width == 0 {
+		width = max(1, height*c.HSize()/c.VSize())
+	}
+
+	if height == 0 {
+		height = max(1, width*c.VSize()/c.HSize())
+	}
+
+	resized := camera.New(width, height, c.FieldOfView())
+	resized.SetTransform(c.Transform())
+
+	return resized
+}
+
+func formatNames() []string {
+	names := make([]string, 0, len(formats))
+	for name := range formats {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+func max(a, b int) int {
+	if a > b {
+		return a
+	}
+
+	return b
+}
+
+// progressBar draws the progress of rendering on a single terminal line.
+type progressBar struct {
+	out   io.Writer
+	width int
+}
+
+// Update redraws the bar with the given number of rendered rows.
+func (b *progressBar) Update(done, total int) {
+	filled := b.width * done / total
+
+	fmt.Fprintf(b.out, "\r[%s%s] %3d%% %d/%d rows",
+		strings.Repeat("#", filled), strings.Repeat(".", b.width-filled), 100*done/total, done, total)
 
+	if done == total {
+		fmt.Fprintln(b.out)
+	}
 }
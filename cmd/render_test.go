@@ -0,0 +1,147 @@
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tyz910/ray-tracer-challenge/internal/canvas/image"
+)
+
+const testScene = `
+- add: camera
+  width: 40
+  height: 20
+  field-of-view: 1.0
+  from: [ 0, 0, -5 ]
+  to: [ 0, 0, 0 ]
+  up: [ 0, 1, 0 ]
+- add: light
+  at: [ -10, 10, -10 ]
+  intensity: [ 1, 1, 1 ]
+- add: sphere
+`
+
+// writeScene writes the scene file into a temporary directory and returns its name.
+func writeScene(t *testing.T, contents string) string {
+	filename := filepath.Join(t.TempDir(), "scene.yml")
+	require.NoError(t, ioutil.WriteFile(filename, []byte(contents), 0644))
+
+	return filename
+}
+
+// Exit codes of the command
+func TestRunExitCodes(t *testing.T) {
+	sceneFile := writeScene(t, testScene)
+	brokenFile := writeScene(t, "- add: sphere\n  radius: 2\n")
+	badCameraFile := writeScene(t, strings.Replace(testScene, "to: [ 0, 0, 0 ]", "to: [ 0, 0, -5 ]", 1))
+	output := filepath.Join(t.TempDir(), "out.ppm")
+
+	tests := []struct {
+		Name     string
+		Args     []string
+		Expected int
+	}{
+		{Name: "bad flag", Args: []string{"-colors", "8", sceneFile, output}, Expected: exitUsage},
+		{Name: "missing argument", Args: []string{sceneFile}, Expected: exitUsage},
+		{Name: "unknown format", Args: []string{"-format", "gif", sceneFile, output}, Expected: exitUsage},
+		{Name: "unknown extension", Args: []string{sceneFile, filepath.Join(t.TempDir(), "out.jpg")}, Expected: exitUsage},
+		{Name: "missing scene", Args: []string{filepath.Join(t.TempDir(), "missing.yml"), output}, Expected: exitParseError},
+		{Name: "broken scene", Args: []string{brokenFile, output}, Expected: exitParseError},
+		{Name: "degenerate camera", Args: []string{badCameraFile, output}, Expected: exitParseError},
+		{Name: "rendered scene", Args: []string{sceneFile, output}, Expected: exitOK},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			// Given
+			var stderr bytes.Buffer
+
+			// When
+			code := run(test.Args, &stderr)
+
+			// Then
+			assert.Equal(t, test.Expected, code, stderr.String())
+		})
+	}
+}
+
+// Rendering a scene to every supported format
+func TestRunFormats(t *testing.T) {
+	sceneFile := writeScene(t, testScene)
+
+	for _, format := range formatNames() {
+		t.Run(format, func(t *testing.T) {
+			// Given
+			var stderr bytes.Buffer
+			output := filepath.Join(t.TempDir(), "out")
+
+			// When
+			code := run([]string{"-format", format, sceneFile, output}, &stderr)
+
+			// Then
+			require.Equal(t, exitOK, code, stderr.String())
+			assert.FileExists(t, output)
+		})
+	}
+}
+
+// Overriding the image size keeps the aspect ratio of the camera
+func TestRunResize(t *testing.T) {
+	tests := []struct {
+		Name   string
+		Args   []string
+		Width  int
+		Height int
+	}{
+		{Name: "width", Args: []string{"-width", "20"}, Width: 20, Height: 10},
+		{Name: "height", Args: []string{"-height", "30"}, Width: 60, Height: 30},
+		{Name: "both", Args: []string{"-width", "15", "-height", "15"}, Width: 15, Height: 15},
+		{Name: "none", Args: nil, Width: 40, Height: 20},
+	}
+
+	sceneFile := writeScene(t, testScene)
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			// Given
+			var stderr bytes.Buffer
+			output := filepath.Join(t.TempDir(), "out.ppm")
+			args := append(test.Args, sceneFile, output)
+
+			// When
+			code := run(args, &stderr)
+
+			// Then
+			require.Equal(t, exitOK, code, stderr.String())
+
+			cnv, err := image.LoadPPM(output)
+			require.NoError(t, err)
+			assert.Equal(t, test.Width, cnv.Width())
+			assert.Equal(t, test.Height, cnv.Height())
+		})
+	}
+}
+
+// Printing the warnings of the scene
+func TestRunWarnings(t *testing.T) {
+	// Given
+	sceneFile := writeScene(t, testScene+"- add: obj\n  file: model.obj\n")
+	objFile := "v -1 1 0\nv -1 0 0\nv 1 0 0\nf 1 2 3\ng model\ns off\n"
+	require.NoError(t, ioutil.WriteFile(filepath.Join(filepath.Dir(sceneFile), "model.obj"), []byte(objFile), 0644))
+
+	var stderr bytes.Buffer
+	output := filepath.Join(t.TempDir(), "out.ppm")
+
+	// When
+	code := run([]string{sceneFile, output}, &stderr)
+
+	// Then
+	require.Equal(t, exitOK, code, stderr.String())
+	assert.Contains(t, stderr.String(), `warning: line 14: 1 unrecognized lines of "model.obj" were ignored`)
+}
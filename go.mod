@@ -1,5 +1,5 @@
 module github.com/tyz910/ray-tracer-challenge
 
-go 1.13
+go 1.15
 
 require github.com/stretchr/testify v1.4.0
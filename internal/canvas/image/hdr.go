@@ -0,0 +1,324 @@
+package image
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/tyz910/ray-tracer-challenge/internal/canvas"
+	"github.com/tyz910/ray-tracer-challenge/internal/canvas/color"
+)
+
+const (
+	hdrFormat = "32-bit_rle_rgbe"
+
+	// scanlines are run-length encoded only if their width is within these limits
+	hdrMinRLEWidth = 8
+	hdrMaxRLEWidth = 0x7fff
+
+	// runs shorter than this are cheaper to store as literal bytes
+	hdrMinRun = 4
+)
+
+// HDR represents Radiance RGBE image format. Every color is stored as three 8-bit mantissas
+// sharing an 8-bit exponent, so the highlights brighter than 1.0 are preserved.
+// Negative color channels can't be represented and are stored as zero.
+type HDR struct {
+	cnv canvas.Canvas
+}
+
+// NewHDR creates new Radiance HDR image.
+func NewHDR(cnv canvas.Canvas) *HDR {
+	return &HDR{
+		cnv: cnv,
+	}
+}
+
+// WriteTo encodes the image to the writer and returns the number of bytes written.
+// Scanlines are run-length encoded when their width allows it.
+func (h *HDR) WriteTo(w io.Writer) (int64, error) {
+	cw := &countingWriter{w: w}
+	bw := bufio.NewWriter(cw)
+
+	width, height := h.cnv.Width(), h.cnv.Height()
+	fmt.Fprintf(bw, "#?RADIANCE\nFORMAT=%s\n\n-Y %d +X %d\n", hdrFormat, height, width)
+
+	scanline := make([][4]byte, width)
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			scanline[x] = toRGBE(h.cnv.Pixel(x, y))
+		}
+
+		if width < hdrMinRLEWidth || width > hdrMaxRLEWidth {
+			for _, p := range scanline {
+				bw.Write(p[:])
+			}
+
+			continue
+		}
+
+		bw.Write([]byte{2, 2, byte(width >> 8), byte(width & 0xff)})
+
+		channel := make([]byte, width)
+		for i := 0; i < 4; i++ {
+			for x, p := range scanline {
+				channel[x] = p[i]
+			}
+
+			writeRLE(bw, channel)
+		}
+	}
+
+	err := bw.Flush()
+
+	return cw.n, err
+}
+
+// Save saves the .hdr image to disk.
+func (h *HDR) Save(filename string) error {
+	return saveFile(filename, h)
+}
+
+// writeRLE writes the bytes as a sequence of runs of a repeated byte and literal byte sequences.
+func writeRLE(w *bufio.Writer, data []byte) {
+	cur := 0
+	for cur < len(data) {
+		// find the next run long enough to be worth encoding
+		begRun := cur
+		runCount, oldRunCount := 0, 0
+
+		for runCount < hdrMinRun && begRun < len(data) {
+			begRun += runCount
+			oldRunCount = runCount
+			runCount = 1
+
+			for begRun+runCount < len(data) && runCount < 127 && data[begRun] == data[begRun+runCount] {
+				runCount++
+			}
+		}
+
+		// a short run right before the long one is still stored as a run
+		if oldRunCount > 1 && oldRunCount == begRun-cur {
+			w.WriteByte(byte(128 + oldRunCount))
+			w.WriteByte(data[cur])
+			cur = begRun
+		}
+
+		for cur < begRun {
+			n := begRun - cur
+			if n > 128 {
+				n = 128
+			}
+
+			w.WriteByte(byte(n))
+			w.Write(data[cur : cur+n])
+			cur += n
+		}
+
+		if runCount >= hdrMinRun {
+			w.WriteByte(byte(128 + runCount))
+			w.WriteByte(data[begRun])
+			cur += runCount
+		}
+	}
+}
+
+// LoadHDR reads the Radiance HDR image file with the given name.
+func LoadHDR(filename string) (canvas.Canvas, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return canvas.Canvas{}, err
+	}
+	defer f.Close()
+
+	return DecodeHDR(f)
+}
+
+// DecodeHDR reads a Radiance RGBE image with flat or run-length encoded scanlines.
+// Only the standard orientation with the rows stored from top to bottom is supported,
+// and the exposure recorded in the header is ignored.
+func DecodeHDR(r io.Reader) (canvas.Canvas, error) {
+	br := bufio.NewReader(r)
+
+	width, height, err := readHDRHeader(br)
+	if err != nil {
+		return canvas.Canvas{}, fmt.Errorf("hdr: %v", err)
+	}
+
+	cnv := canvas.New(width, height)
+	scanline := make([][4]byte, width)
+
+	for y := 0; y < height; y++ {
+		if err := readScanline(br, scanline); err != nil {
+			return canvas.Canvas{}, fmt.Errorf("hdr: scanline %d: %v", y, err)
+		}
+
+		for x, p := range scanline {
+			cnv.SetPixel(x, y, fromRGBE(p))
+		}
+	}
+
+	return cnv, nil
+}
+
+func readHDRHeader(r *bufio.Reader) (width, height int, err error) {
+	line, err := readHeaderLine(r)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	if !strings.HasPrefix(line, "#?") {
+		return 0, 0, fmt.Errorf("missing Radiance signature")
+	}
+
+	// the header ends with an empty line
+	for {
+		line, err = readHeaderLine(r)
+		if err != nil {
+			return 0, 0, err
+		}
+
+		if line == "" {
+			break
+		}
+
+		if strings.HasPrefix(line, "FORMAT=") && line != "FORMAT="+hdrFormat {
+			return 0, 0, fmt.Errorf("unsupported format %q", strings.TrimPrefix(line, "FORMAT="))
+		}
+	}
+
+	line, err = readHeaderLine(r)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	fields := strings.Fields(line)
+	if len(fields) != 4 || fields[0] != "-Y" || fields[2] != "+X" {
+		return 0, 0, fmt.Errorf("unsupported resolution %q", line)
+	}
+
+	height, errY := strconv.Atoi(fields[1])
+	width, errX := strconv.Atoi(fields[3])
+	if errY != nil || errX != nil || width < 1 || height < 1 || width > maxPixels || height > maxPixels {
+		return 0, 0, fmt.Errorf("invalid resolution %q", line)
+	}
+
+	if err := checkSize(width, height); err != nil {
+		return 0, 0, err
+	}
+
+	return width, height, nil
+}
+
+func readHeaderLine(r *bufio.Reader) (string, error) {
+	line, err := r.ReadString('\n')
+	if err != nil {
+		return "", unexpectedEOF(err)
+	}
+
+	return strings.TrimRight(line, "\r\n"), nil
+}
+
+// readScanline reads a single row of pixels, detecting whether it is run-length encoded.
+func readScanline(r *bufio.Reader, scanline [][4]byte) error {
+	width := len(scanline)
+
+	var first [4]byte
+	if _, err := io.ReadFull(r, first[:]); err != nil {
+		return unexpectedEOF(err)
+	}
+
+	rle := width >= hdrMinRLEWidth && width <= hdrMaxRLEWidth &&
+		first[0] == 2 && first[1] == 2 && first[2]&0x80 == 0
+
+	if !rle {
+		scanline[0] = first
+		for x := 1; x < width; x++ {
+			if _, err := io.ReadFull(r, scanline[x][:]); err != nil {
+				return unexpectedEOF(err)
+			}
+		}
+
+		return nil
+	}
+
+	if w := int(first[2])<<8 | int(first[3]); w != width {
+		return fmt.Errorf("scanline width %d doesn't match image width %d", w, width)
+	}
+
+	for i := 0; i < 4; i++ {
+		for x := 0; x < width; {
+			count, err := r.ReadByte()
+			if err != nil {
+				return unexpectedEOF(err)
+			}
+
+			n := int(count)
+			if n > 128 {
+				n -= 128
+			}
+
+			if n == 0 || x+n > width {
+				return fmt.Errorf("invalid run length")
+			}
+
+			if count > 128 {
+				v, err := r.ReadByte()
+				if err != nil {
+					return unexpectedEOF(err)
+				}
+
+				for end := x + n; x < end; x++ {
+					scanline[x][i] = v
+				}
+
+				continue
+			}
+
+			for end := x + n; x < end; x++ {
+				v, err := r.ReadByte()
+				if err != nil {
+					return unexpectedEOF(err)
+				}
+
+				scanline[x][i] = v
+			}
+		}
+	}
+
+	return nil
+}
+
+// toRGBE converts the color to three mantissas and the shared exponent of its brightest channel.
+func toRGBE(c color.Color) [4]byte {
+	r, g, b := math.Max(c.Red(), 0.0), math.Max(c.Green(), 0.0), math.Max(c.Blue(), 0.0)
+
+	v := math.Max(r, math.Max(g, b))
+	if v < 1e-32 {
+		return [4]byte{}
+	}
+
+	m, e := math.Frexp(v)
+	if e > 127 {
+		// too bright to represent, store the brightest possible color instead
+		return [4]byte{255, 255, 255, 255}
+	}
+
+	scale := m * 256.0 / v
+
+	return [4]byte{byte(r * scale), byte(g * scale), byte(b * scale), byte(e + 128)}
+}
+
+func fromRGBE(p [4]byte) color.Color {
+	if p[3] == 0 {
+		return color.Black()
+	}
+
+	f := math.Ldexp(1.0, int(p[3])-(128+8))
+
+	return color.New(float64(p[0])*f, float64(p[1])*f, float64(p[2])*f)
+}
@@ -0,0 +1,144 @@
+package image_test
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tyz910/ray-tracer-challenge/internal/canvas"
+	"github.com/tyz910/ray-tracer-challenge/internal/canvas/color"
+	"github.com/tyz910/ray-tracer-challenge/internal/canvas/image"
+)
+
+// Constructing the Radiance HDR header
+func TestHDRHeader(t *testing.T) {
+	// Given
+	c := canvas.New(5, 3)
+
+	// When
+	var buf bytes.Buffer
+	n, err := image.NewHDR(c).WriteTo(&buf)
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, int64(buf.Len()), n)
+	assert.True(t, strings.HasPrefix(buf.String(), "#?RADIANCE\nFORMAT=32-bit_rle_rgbe\n\n-Y 3 +X 5\n"))
+}
+
+// Writing and reading back the HDR image preserves bright colors
+func TestHDRRoundTrip(t *testing.T) {
+	tests := []struct {
+		Width  int
+		Height int
+	}{
+		// too narrow for run-length encoding
+		{Width: 4, Height: 3},
+		// run-length encoded
+		{Width: 300, Height: 4},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("%dx%d", test.Width, test.Height), func(t *testing.T) {
+			// Given
+			c := canvas.New(test.Width, test.Height)
+			for x := 0; x < test.Width; x++ {
+				// a mix of short and long runs and literal bytes
+				c.SetPixel(x, 0, color.New(float64(x/5), 0.5, 0.0))
+				c.SetPixel(x, 1, color.New(float64(x%3)*8.0, 1.0, 0.25))
+			}
+			c.SetPixel(test.Width-1, test.Height-1, color.New(1000.0, -1.0, 0.001))
+			var buf bytes.Buffer
+
+			// When
+			_, err := image.NewHDR(c).WriteTo(&buf)
+			require.NoError(t, err)
+
+			decoded, err := image.DecodeHDR(&buf)
+
+			// Then
+			require.NoError(t, err)
+			require.Equal(t, test.Width, decoded.Width())
+			require.Equal(t, test.Height, decoded.Height())
+
+			for y := 0; y < test.Height; y++ {
+				for x := 0; x < test.Width; x++ {
+					expected, actual := c.Pixel(x, y), decoded.Pixel(x, y)
+					brightest := maxChannel(expected)
+
+					// the mantissas are relative to the brightest channel and negative channels are dropped
+					assert.InDelta(t, clampNegative(expected.Red()), actual.Red(), brightest/128.0)
+					assert.InDelta(t, clampNegative(expected.Green()), actual.Green(), brightest/128.0)
+					assert.InDelta(t, clampNegative(expected.Blue()), actual.Blue(), brightest/128.0)
+				}
+			}
+		})
+	}
+}
+
+// Reading a flat scanline of an HDR image
+func TestDecodeHDRFlat(t *testing.T) {
+	// Given
+	hdr := "#?RGBE\nEXPOSURE=1.0\n\n-Y 1 +X 2\n" + string([]byte{
+		128, 64, 0, 129,
+		0, 0, 0, 0,
+	})
+
+	// When
+	c, err := image.DecodeHDR(strings.NewReader(hdr))
+
+	// Then
+	require.NoError(t, err)
+	assert.True(t, c.Pixel(0, 0).Equal(color.New(1.0, 0.5, 0.0)))
+	assert.True(t, c.Pixel(1, 0).Equal(color.Black()))
+}
+
+// Reading malformed HDR images
+func TestDecodeHDRMalformed(t *testing.T) {
+	tests := []struct {
+		Name string
+		HDR  string
+	}{
+		{Name: "missing signature", HDR: "RADIANCE\n\n-Y 1 +X 1\n\x00\x00\x00\x00"},
+		{Name: "unsupported format", HDR: "#?RADIANCE\nFORMAT=32-bit_rle_xyze\n\n-Y 1 +X 1\n\x00\x00\x00\x00"},
+		{Name: "unsupported orientation", HDR: "#?RADIANCE\n\n+Y 1 +X 1\n\x00\x00\x00\x00"},
+		{Name: "too many pixels", HDR: "#?RADIANCE\n\n-Y 10000 +X 10000\n\x00\x00\x00\x00"},
+		{Name: "truncated data", HDR: "#?RADIANCE\n\n-Y 1 +X 2\n\x00\x00\x00\x00"},
+		{Name: "wrong scanline width", HDR: "#?RADIANCE\n\n-Y 1 +X 8\n\x02\x02\x00\x09"},
+		{Name: "run overflowing scanline", HDR: "#?RADIANCE\n\n-Y 1 +X 8\n\x02\x02\x00\x08\x89\x00"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			// When
+			_, err := image.DecodeHDR(strings.NewReader(test.HDR))
+
+			// Then
+			assert.Error(t, err)
+		})
+	}
+}
+
+func maxChannel(c color.Color) float64 {
+	m := c.Red()
+	if c.Green() > m {
+		m = c.Green()
+	}
+
+	if c.Blue() > m {
+		m = c.Blue()
+	}
+
+	return m
+}
+
+func clampNegative(v float64) float64 {
+	if v < 0.0 {
+		return 0.0
+	}
+
+	return v
+}
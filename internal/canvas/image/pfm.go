@@ -0,0 +1,139 @@
+package image
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"strconv"
+
+	"github.com/tyz910/ray-tracer-challenge/internal/canvas"
+	"github.com/tyz910/ray-tracer-challenge/internal/canvas/color"
+)
+
+// PFM represents Portable Float Map image format. Colors are stored as 32-bit floats without clamping,
+// so the highlights brighter than 1.0 are preserved.
+type PFM struct {
+	cnv canvas.Canvas
+}
+
+// NewPFM creates new PFM image.
+func NewPFM(cnv canvas.Canvas) *PFM {
+	return &PFM{
+		cnv: cnv,
+	}
+}
+
+// WriteTo encodes the image to the writer and returns the number of bytes written.
+// The pixel data is little-endian and, as the format requires, stored from the bottom row to the top.
+func (p *PFM) WriteTo(w io.Writer) (int64, error) {
+	cw := &countingWriter{w: w}
+	bw := bufio.NewWriter(cw)
+
+	// the negative scale marks little-endian data
+	fmt.Fprintf(bw, "PF\n%d %d\n-1.0\n", p.cnv.Width(), p.cnv.Height())
+
+	var buf [12]byte
+	for y := p.cnv.Height() - 1; y >= 0; y-- {
+		for x := 0; x < p.cnv.Width(); x++ {
+			c := p.cnv.Pixel(x, y)
+			binary.LittleEndian.PutUint32(buf[0:], math.Float32bits(float32(c.Red())))
+			binary.LittleEndian.PutUint32(buf[4:], math.Float32bits(float32(c.Green())))
+			binary.LittleEndian.PutUint32(buf[8:], math.Float32bits(float32(c.Blue())))
+			bw.Write(buf[:])
+		}
+	}
+
+	err := bw.Flush()
+
+	return cw.n, err
+}
+
+// Save saves the .pfm image to disk.
+func (p *PFM) Save(filename string) error {
+	return saveFile(filename, p)
+}
+
+// LoadPFM reads the PFM image file with the given name.
+func LoadPFM(filename string) (canvas.Canvas, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return canvas.Canvas{}, err
+	}
+	defer f.Close()
+
+	return DecodePFM(f)
+}
+
+// DecodePFM reads a color (PF) or grayscale (Pf) PFM image in either byte order.
+// The magnitude of the scale factor in the header is ignored.
+func DecodePFM(r io.Reader) (canvas.Canvas, error) {
+	d := &ppmDecoder{r: bufio.NewReader(r)}
+
+	magic, err := d.readToken()
+	if err != nil {
+		return canvas.Canvas{}, fmt.Errorf("pfm: reading magic number: %v", err)
+	}
+
+	channels := 0
+	switch magic {
+	case "PF":
+		channels = 3
+	case "Pf":
+		channels = 1
+	default:
+		return canvas.Canvas{}, fmt.Errorf("pfm: unsupported magic number %q", magic)
+	}
+
+	width, err := d.readInt("width", 1, maxPixels)
+	if err != nil {
+		return canvas.Canvas{}, fmt.Errorf("pfm: %v", err)
+	}
+
+	height, err := d.readInt("height", 1, maxPixels)
+	if err != nil {
+		return canvas.Canvas{}, fmt.Errorf("pfm: %v", err)
+	}
+
+	if err := checkSize(width, height); err != nil {
+		return canvas.Canvas{}, fmt.Errorf("pfm: %v", err)
+	}
+
+	token, err := d.readToken()
+	if err != nil {
+		return canvas.Canvas{}, fmt.Errorf("pfm: reading scale: %v", err)
+	}
+
+	scale, err := strconv.ParseFloat(token, 64)
+	if err != nil || scale == 0.0 {
+		return canvas.Canvas{}, fmt.Errorf("pfm: invalid scale %q", token)
+	}
+
+	var order binary.ByteOrder = binary.BigEndian
+	if scale < 0.0 {
+		order = binary.LittleEndian
+	}
+
+	cnv := canvas.New(width, height)
+	buf := make([]byte, 4*channels)
+	var rgb [3]float64
+
+	for y := height - 1; y >= 0; y-- {
+		for x := 0; x < width; x++ {
+			if _, err := io.ReadFull(d.r, buf); err != nil {
+				return canvas.Canvas{}, fmt.Errorf("pfm: pixel (%d, %d): %v", x, y, unexpectedEOF(err))
+			}
+
+			for i := range rgb {
+				v := order.Uint32(buf[4*(i%channels):])
+				rgb[i] = float64(math.Float32frombits(v))
+			}
+
+			cnv.SetPixel(x, y, color.New(rgb[0], rgb[1], rgb[2]))
+		}
+	}
+
+	return cnv, nil
+}
@@ -0,0 +1,102 @@
+package image_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tyz910/ray-tracer-challenge/internal/canvas"
+	"github.com/tyz910/ray-tracer-challenge/internal/canvas/color"
+	"github.com/tyz910/ray-tracer-challenge/internal/canvas/image"
+)
+
+// Constructing the PFM image
+func TestPFM(t *testing.T) {
+	// Given
+	c := canvas.New(2, 2)
+	c.SetPixel(0, 0, color.New(4.5, 0.0, -1.0))
+	c.SetPixel(1, 1, color.New(0.25, 0.5, 1.0))
+	var buf bytes.Buffer
+
+	// When
+	n, err := image.NewPFM(c).WriteTo(&buf)
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, int64(buf.Len()), n)
+
+	header := "PF\n2 2\n-1.0\n"
+	require.True(t, strings.HasPrefix(buf.String(), header))
+
+	floats := make([]float32, 12)
+	require.NoError(t, binary.Read(bytes.NewReader(buf.Bytes()[len(header):]), binary.LittleEndian, floats))
+
+	// rows are stored from the bottom to the top
+	assert.Equal(t, []float32{
+		0.0, 0.0, 0.0, 0.25, 0.5, 1.0,
+		4.5, 0.0, -1.0, 0.0, 0.0, 0.0,
+	}, floats)
+}
+
+// Writing and reading back the PFM image preserves colors out of range
+func TestPFMRoundTrip(t *testing.T) {
+	// Given
+	c := canvas.New(3, 2)
+	c.SetPixel(0, 0, color.New(1000.0, 0.5, 0.0))
+	c.SetPixel(2, 1, color.New(-0.25, 2.0, 0.125))
+	var buf bytes.Buffer
+
+	// When
+	_, err := image.NewPFM(c).WriteTo(&buf)
+	require.NoError(t, err)
+
+	decoded, err := image.DecodePFM(&buf)
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, c, decoded)
+}
+
+// Reading big-endian grayscale PFM images
+func TestDecodePFMGrayscale(t *testing.T) {
+	// Given
+	var buf bytes.Buffer
+	buf.WriteString("Pf\n2 1\n1.0\n")
+	require.NoError(t, binary.Write(&buf, binary.BigEndian, []float32{0.5, 3.0}))
+
+	// When
+	c, err := image.DecodePFM(&buf)
+
+	// Then
+	require.NoError(t, err)
+	assert.True(t, c.Pixel(0, 0).Equal(color.New(0.5, 0.5, 0.5)))
+	assert.True(t, c.Pixel(1, 0).Equal(color.New(3.0, 3.0, 3.0)))
+}
+
+// Reading malformed PFM images
+func TestDecodePFMMalformed(t *testing.T) {
+	tests := []struct {
+		Name string
+		PFM  string
+	}{
+		{Name: "wrong magic number", PFM: "P6\n1 1\n-1.0\n"},
+		{Name: "invalid size", PFM: "PF\n1 x\n-1.0\n"},
+		{Name: "too many pixels", PFM: "PF\n10000 10000\n-1.0\n"},
+		{Name: "zero scale", PFM: "PF\n1 1\n0\n" + strings.Repeat("\x00", 12)},
+		{Name: "truncated data", PFM: "PF\n2 1\n-1.0\n" + strings.Repeat("\x00", 20)},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			// When
+			_, err := image.DecodePFM(strings.NewReader(test.PFM))
+
+			// Then
+			assert.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,206 @@
+package image
+
+import (
+	"bytes"
+	"fmt"
+	stdimage "image"
+	stdcolor "image/color"
+	"image/png"
+	"io"
+	"math"
+	"os"
+
+	"github.com/tyz910/ray-tracer-challenge/internal/canvas"
+	"github.com/tyz910/ray-tracer-challenge/internal/canvas/color"
+)
+
+// BitDepth is the number of bits per color channel.
+type BitDepth int
+
+const (
+	// Depth8 stores every color channel in a byte.
+	Depth8 BitDepth = 8
+	// Depth16 stores every color channel in two bytes.
+	Depth16 BitDepth = 16
+)
+
+// CanvasImage is a view of the canvas as an image of the standard library. The pixels are not copied,
+// so the changes of the canvas are visible through the view. Colors are clamped to the range 0..1
+// and reported with 16 bits per channel, the image is fully opaque.
+type CanvasImage struct {
+	cnv canvas.Canvas
+}
+
+// NewCanvasImage creates new view of the canvas as an image of the standard library.
+func NewCanvasImage(cnv canvas.Canvas) *CanvasImage {
+	return &CanvasImage{
+		cnv: cnv,
+	}
+}
+
+// ColorModel returns the color model of the image.
+func (img *CanvasImage) ColorModel() stdcolor.Model {
+	return stdcolor.RGBA64Model
+}
+
+// Bounds returns the domain of the image, which starts at the origin like the canvas.
+func (img *CanvasImage) Bounds() stdimage.Rectangle {
+	return stdimage.Rect(0, 0, img.cnv.Width(), img.cnv.Height())
+}
+
+// At returns the color of the pixel at (x, y), or transparent black outside of the bounds.
+func (img *CanvasImage) At(x, y int) stdcolor.Color {
+	if !(stdimage.Point{X: x, Y: y}).In(img.Bounds()) {
+		return stdcolor.RGBA64{}
+	}
+
+	c := img.cnv.Pixel(x, y)
+
+	return stdcolor.RGBA64{
+		R: uint16(convertChannel(c.Red(), math.MaxUint16)),
+		G: uint16(convertChannel(c.Green(), math.MaxUint16)),
+		B: uint16(convertChannel(c.Blue(), math.MaxUint16)),
+		A: math.MaxUint16,
+	}
+}
+
+// ToImage copies the canvas to a new image of the standard library with the given bit depth.
+// Colors are clamped to the range 0..1, the image is fully opaque. Use CanvasImage to avoid the copy.
+func ToImage(cnv canvas.Canvas, depth BitDepth) stdimage.Image {
+	rect := stdimage.Rect(0, 0, cnv.Width(), cnv.Height())
+
+	if depth == Depth16 {
+		img := stdimage.NewRGBA64(rect)
+		for y := 0; y < cnv.Height(); y++ {
+			for x := 0; x < cnv.Width(); x++ {
+				c := cnv.Pixel(x, y)
+				img.SetRGBA64(x, y, stdcolor.RGBA64{
+					R: uint16(convertChannel(c.Red(), math.MaxUint16)),
+					G: uint16(convertChannel(c.Green(), math.MaxUint16)),
+					B: uint16(convertChannel(c.Blue(), math.MaxUint16)),
+					A: math.MaxUint16,
+				})
+			}
+		}
+
+		return img
+	}
+
+	img := stdimage.NewRGBA(rect)
+	for y := 0; y < cnv.Height(); y++ {
+		for x := 0; x < cnv.Width(); x++ {
+			c := cnv.Pixel(x, y)
+			img.SetRGBA(x, y, stdcolor.RGBA{
+				R: uint8(convertColorChannel(c.Red())),
+				G: uint8(convertColorChannel(c.Green())),
+				B: uint8(convertColorChannel(c.Blue())),
+				A: math.MaxUint8,
+			})
+		}
+	}
+
+	return img
+}
+
+// FromImage creates a canvas from an image of the standard library. The colors are scaled to the range 0..1.
+// Transparency is dropped, so translucent pixels appear as if drawn over black.
+// Images without pixels or with more than maxPixels pixels are rejected.
+func FromImage(img stdimage.Image) (canvas.Canvas, error) {
+	b := img.Bounds()
+	if b.Empty() {
+		return canvas.Canvas{}, fmt.Errorf("image has no pixels")
+	}
+
+	if err := checkSize(b.Dx(), b.Dy()); err != nil {
+		return canvas.Canvas{}, err
+	}
+
+	cnv := canvas.New(b.Dx(), b.Dy())
+
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		for x := b.Min.X; x < b.Max.X; x++ {
+			r, g, bl, _ := img.At(x, y).RGBA()
+			cnv.SetPixel(x-b.Min.X, y-b.Min.Y, color.New(
+				float64(r)/math.MaxUint16,
+				float64(g)/math.MaxUint16,
+				float64(bl)/math.MaxUint16,
+			))
+		}
+	}
+
+	return cnv, nil
+}
+
+// PNG represents Portable Network Graphics image format.
+type PNG struct {
+	cnv   canvas.Canvas
+	depth BitDepth
+}
+
+// NewPNG creates new PNG image with 8 bits per channel.
+func NewPNG(cnv canvas.Canvas) *PNG {
+	return &PNG{
+		cnv:   cnv,
+		depth: Depth8,
+	}
+}
+
+// Depth returns the number of bits per color channel.
+func (p *PNG) Depth() BitDepth {
+	return p.depth
+}
+
+// SetDepth changes the number of bits per color channel.
+func (p *PNG) SetDepth(depth BitDepth) {
+	p.depth = depth
+}
+
+// WriteTo encodes the image to the writer and returns the number of bytes written.
+func (p *PNG) WriteTo(w io.Writer) (int64, error) {
+	cw := &countingWriter{w: w}
+	err := png.Encode(cw, ToImage(p.cnv, p.depth))
+
+	return cw.n, err
+}
+
+// Save saves the .png image to disk.
+func (p *PNG) Save(filename string) error {
+	return saveFile(filename, p)
+}
+
+// LoadPNG reads the PNG image file with the given name.
+func LoadPNG(filename string) (canvas.Canvas, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return canvas.Canvas{}, err
+	}
+	defer f.Close()
+
+	return DecodePNG(f)
+}
+
+// DecodePNG reads a PNG image of any bit depth and color type.
+func DecodePNG(r io.Reader) (canvas.Canvas, error) {
+	// check the size in the header before the pixels are allocated, then decode the image from the start
+	var header bytes.Buffer
+	cfg, err := png.DecodeConfig(io.TeeReader(r, &header))
+	if err != nil {
+		return canvas.Canvas{}, err
+	}
+
+	if err := checkSize(cfg.Width, cfg.Height); err != nil {
+		return canvas.Canvas{}, fmt.Errorf("png: %v", err)
+	}
+
+	img, err := png.Decode(io.MultiReader(&header, r))
+	if err != nil {
+		return canvas.Canvas{}, err
+	}
+
+	cnv, err := FromImage(img)
+	if err != nil {
+		return canvas.Canvas{}, fmt.Errorf("png: %v", err)
+	}
+
+	return cnv, nil
+}
@@ -0,0 +1,183 @@
+package image_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"hash/crc32"
+	stdimage "image"
+	stdcolor "image/color"
+	"image/png"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tyz910/ray-tracer-challenge/internal/canvas"
+	"github.com/tyz910/ray-tracer-challenge/internal/canvas/color"
+	"github.com/tyz910/ray-tracer-challenge/internal/canvas/image"
+)
+
+// Converting a canvas to an 8-bit image
+func TestToImage(t *testing.T) {
+	// Given
+	c := canvas.New(3, 2)
+	c.SetPixel(0, 0, color.New(1.5, 0.0, 0.0))
+	c.SetPixel(1, 0, color.New(0.0, 0.5, 0.0))
+	c.SetPixel(2, 1, color.New(-0.5, 0.0, 1.0))
+
+	// When
+	img := image.ToImage(c, image.Depth8)
+
+	// Then
+	require.IsType(t, &stdimage.RGBA{}, img)
+	assert.Equal(t, stdimage.Rect(0, 0, 3, 2), img.Bounds())
+	assert.Equal(t, stdcolor.RGBA{R: 255, G: 0, B: 0, A: 255}, img.At(0, 0))
+	assert.Equal(t, stdcolor.RGBA{R: 0, G: 128, B: 0, A: 255}, img.At(1, 0))
+	assert.Equal(t, stdcolor.RGBA{R: 0, G: 0, B: 255, A: 255}, img.At(2, 1))
+	assert.Equal(t, stdcolor.RGBA{R: 0, G: 0, B: 0, A: 255}, img.At(0, 1))
+}
+
+// Converting a canvas to a 16-bit image
+func TestToImage16(t *testing.T) {
+	// Given
+	c := canvas.New(1, 1)
+	c.SetPixel(0, 0, color.New(1.0, 0.5, 0.0))
+
+	// When
+	img := image.ToImage(c, image.Depth16)
+
+	// Then
+	require.IsType(t, &stdimage.RGBA64{}, img)
+	assert.Equal(t, stdcolor.RGBA64{R: 65535, G: 32768, B: 0, A: 65535}, img.At(0, 0))
+}
+
+// Creating a canvas from an image
+func TestFromImage(t *testing.T) {
+	// Given
+	img := stdimage.NewNRGBA(stdimage.Rect(10, 20, 12, 21))
+	img.SetNRGBA(10, 20, stdcolor.NRGBA{R: 255, G: 51, B: 0, A: 255})
+	img.SetNRGBA(11, 20, stdcolor.NRGBA{R: 0, G: 0, B: 204, A: 255})
+
+	// When
+	c, err := image.FromImage(img)
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, 2, c.Width())
+	assert.Equal(t, 1, c.Height())
+	assert.True(t, c.Pixel(0, 0).Equal(color.New(1.0, 0.2, 0.0)))
+	assert.True(t, c.Pixel(1, 0).Equal(color.New(0.0, 0.0, 0.8)))
+}
+
+// Creating a canvas from an image without pixels
+func TestFromImageEmpty(t *testing.T) {
+	// Given
+	img := stdimage.NewRGBA(stdimage.Rect(0, 0, 0, 0))
+
+	// When
+	_, err := image.FromImage(img)
+
+	// Then
+	assert.Error(t, err)
+}
+
+// Viewing a canvas as an image
+func TestCanvasImage(t *testing.T) {
+	// Given
+	c := canvas.New(3, 2)
+	c.SetPixel(0, 0, color.New(1.5, 0.0, 0.0))
+	img := image.NewCanvasImage(c)
+
+	// When
+	c.SetPixel(1, 0, color.New(0.0, 0.5, 0.0))
+
+	// Then
+	assert.Equal(t, stdcolor.RGBA64Model, img.ColorModel())
+	assert.Equal(t, stdimage.Rect(0, 0, 3, 2), img.Bounds())
+	assert.Equal(t, stdcolor.RGBA64{R: 65535, G: 0, B: 0, A: 65535}, img.At(0, 0))
+	assert.Equal(t, stdcolor.RGBA64{R: 0, G: 32768, B: 0, A: 65535}, img.At(1, 0))
+	assert.Equal(t, stdcolor.RGBA64{R: 0, G: 0, B: 0, A: 65535}, img.At(2, 1))
+	assert.Equal(t, stdcolor.RGBA64{}, img.At(3, 0))
+}
+
+// Encoding and decoding PNG images
+func TestPNG(t *testing.T) {
+	tests := []struct {
+		Depth image.BitDepth
+		Model stdcolor.Model
+	}{
+		{Depth: image.Depth8, Model: stdcolor.RGBAModel},
+		{Depth: image.Depth16, Model: stdcolor.RGBA64Model},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("%d-bit PNG", test.Depth), func(t *testing.T) {
+			// Given
+			c := canvas.New(4, 3)
+			c.SetPixel(0, 0, color.New(1.0, 0.0, 0.0))
+			c.SetPixel(3, 2, color.New(0.2, 0.4, 0.6))
+			p := image.NewPNG(c)
+			p.SetDepth(test.Depth)
+			var buf bytes.Buffer
+
+			// When
+			n, err := p.WriteTo(&buf)
+			require.NoError(t, err)
+			data := buf.Bytes()
+
+			cfg, err := png.DecodeConfig(bytes.NewReader(data))
+			require.NoError(t, err)
+
+			decoded, err := image.DecodePNG(bytes.NewReader(data))
+
+			// Then
+			require.NoError(t, err)
+			assert.Equal(t, int64(len(data)), n)
+			assert.Equal(t, test.Depth, p.Depth())
+			assert.Equal(t, test.Model, cfg.ColorModel)
+			assert.True(t, decoded.Pixel(0, 0).Equal(color.New(1.0, 0.0, 0.0)))
+			assert.True(t, decoded.Pixel(3, 2).Equal(color.New(0.2, 0.4, 0.6)))
+			assert.True(t, decoded.Pixel(1, 1).Equal(color.Black()))
+		})
+	}
+}
+
+// Decoding malformed PNG images
+func TestDecodePNGMalformed(t *testing.T) {
+	tests := []struct {
+		Name string
+		PNG  []byte
+	}{
+		{Name: "not a png", PNG: []byte("P3\n1 1\n255\n0 0 0\n")},
+		{Name: "too many pixels", PNG: pngHeader(10000, 10000)},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			// When
+			_, err := image.DecodePNG(bytes.NewReader(test.PNG))
+
+			// Then
+			assert.Error(t, err)
+		})
+	}
+}
+
+// pngHeader returns the signature and the header chunk of an 8-bit RGB PNG image with the given size.
+func pngHeader(width, height int) []byte {
+	chunk := make([]byte, 17)
+	copy(chunk, "IHDR")
+	binary.BigEndian.PutUint32(chunk[4:], uint32(width))
+	binary.BigEndian.PutUint32(chunk[8:], uint32(height))
+	chunk[12] = 8 // bit depth
+	chunk[13] = 2 // truecolor
+
+	var b bytes.Buffer
+	b.WriteString("\x89PNG\r\n\x1a\n")
+	binary.Write(&b, binary.BigEndian, uint32(len(chunk)-4))
+	b.Write(chunk)
+	binary.Write(&b, binary.BigEndian, crc32.ChecksumIEEE(chunk))
+
+	return b.Bytes()
+}
@@ -1,9 +1,11 @@
 package image
 
 import (
+	"bufio"
 	"fmt"
-	"io/ioutil"
+	"io"
 	"math"
+	"os"
 	"strconv"
 	"strings"
 
@@ -13,89 +15,210 @@ import (
 
 const rowMaxLen = 70
 
+// PPMFormat is the variant of the PPM format.
+type PPMFormat int
+
+const (
+	// P3 is the plain PPM format storing colors as ASCII decimal numbers.
+	P3 PPMFormat = iota
+	// P6 is the raw PPM format storing colors as binary bytes.
+	P6
+)
+
+func (f PPMFormat) String() string {
+	if f == P6 {
+		return "P6"
+	}
+
+	return "P3"
+}
+
 // PPM represents Portable Pixmap image format.
+// The image is encoded on demand, so the canvas is streamed to the output without building the file in memory.
 type PPM struct {
-	data string
+	cnv      canvas.Canvas
+	format   PPMFormat
+	lineWrap bool
 }
 
-// NewPPM creates new PPM image.
+// NewPPM creates new PPM image in the plain P3 format with lines wrapped at 70 characters.
+// The image keeps a reference to the canvas and encodes it lazily on every write,
+// so pixels set on the canvas after NewPPM still change the output.
 func NewPPM(cnv canvas.Canvas) *PPM {
-	builder := new(ppmBuilder)
-	builder.WriteHeader(cnv.Width(), cnv.Height())
+	return &PPM{
+		cnv:      cnv,
+		format:   P3,
+		lineWrap: true,
+	}
+}
 
-	for y := 0; y < cnv.Height(); y++ {
-		for x := 0; x < cnv.Width(); x++ {
-			builder.WriteColor(cnv.Pixel(x, y))
-		}
+// Format returns the format the image is encoded in.
+func (ppm *PPM) Format() PPMFormat {
+	return ppm.format
+}
 
-		builder.WriteNewRow()
+// SetFormat changes the format the image is encoded in.
+func (ppm *PPM) SetFormat(format PPMFormat) {
+	ppm.format = format
+}
+
+// LineWrap returns whether lines of the P3 format are wrapped at 70 characters.
+func (ppm *PPM) LineWrap() bool {
+	return ppm.lineWrap
+}
+
+// SetLineWrap changes whether lines of the P3 format are wrapped at 70 characters.
+// Without wrapping every row of the image is written on a single line. The P6 format has no lines.
+func (ppm *PPM) SetLineWrap(lineWrap bool) {
+	ppm.lineWrap = lineWrap
+}
+
+// WriteTo encodes the image to the writer and returns the number of bytes written.
+func (ppm *PPM) WriteTo(w io.Writer) (int64, error) {
+	cw := &countingWriter{w: w}
+	bw := bufio.NewWriter(cw)
+
+	var enc ppmEncoder
+	if ppm.format == P6 {
+		enc = &p6Encoder{w: bw}
+	} else {
+		enc = &p3Encoder{w: bw, lineWrap: ppm.lineWrap}
 	}
 
-	return &PPM{
-		data: builder.String(),
+	enc.WriteHeader(ppm.cnv.Width(), ppm.cnv.Height())
+
+	for y := 0; y < ppm.cnv.Height(); y++ {
+		for x := 0; x < ppm.cnv.Width(); x++ {
+			enc.WriteColor(ppm.cnv.Pixel(x, y))
+		}
+
+		enc.WriteNewRow()
 	}
+
+	// bufio.Writer keeps the first error, so checking it once on flush is enough
+	err := bw.Flush()
+
+	return cw.n, err
 }
 
 func (ppm *PPM) String() string {
-	return ppm.data
+	var sb strings.Builder
+	_, _ = ppm.WriteTo(&sb)
+
+	return sb.String()
 }
 
 // Save saves the .ppm image to disk.
 func (ppm *PPM) Save(filename string) error {
-	return ioutil.WriteFile(filename, []byte(ppm.data), 0644)
+	return saveFile(filename, ppm)
 }
 
-type ppmBuilder struct {
-	data   strings.Builder
-	rowLen int
+// ppmEncoder writes the pixels of the image row by row.
+type ppmEncoder interface {
+	WriteHeader(width, height int)
+	WriteColor(c color.Color)
+	WriteNewRow()
 }
 
-// WriteHeader appends the image header.
-func (pb *ppmBuilder) WriteHeader(width, height int) {
-	pb.data.WriteString(fmt.Sprintf("P3\n%d %d\n255\n", width, height))
+type p3Encoder struct {
+	w        *bufio.Writer
+	lineWrap bool
+	rowLen   int
 }
 
-func (pb *ppmBuilder) WriteNewRow() {
-	pb.data.WriteString("\n")
-	pb.rowLen = 0
+// WriteHeader writes the image header.
+func (e *p3Encoder) WriteHeader(width, height int) {
+	fmt.Fprintf(e.w, "P3\n%d %d\n255\n", width, height)
 }
 
-func (pb *ppmBuilder) WriteColor(c color.Color) {
-	pb.writeColorChannel(c.Red())
-	pb.writeColorChannel(c.Green())
-	pb.writeColorChannel(c.Blue())
+func (e *p3Encoder) WriteNewRow() {
+	e.w.WriteByte('\n')
+	e.rowLen = 0
 }
 
-func (pb *ppmBuilder) writeColorChannel(c float64) {
+func (e *p3Encoder) WriteColor(c color.Color) {
+	e.writeColorChannel(c.Red())
+	e.writeColorChannel(c.Green())
+	e.writeColorChannel(c.Blue())
+}
+
+func (e *p3Encoder) writeColorChannel(c float64) {
 	cStr := strconv.Itoa(convertColorChannel(c))
 	cLen := len(cStr)
 
-	if pb.rowLen+cLen+1 > rowMaxLen {
-		pb.WriteNewRow()
+	if e.lineWrap && e.rowLen+cLen+1 > rowMaxLen {
+		e.WriteNewRow()
 	}
 
-	if pb.rowLen > 0 {
-		pb.data.WriteString(" ")
-		pb.rowLen++
+	if e.rowLen > 0 {
+		e.w.WriteByte(' ')
+		e.rowLen++
 	}
 
-	pb.data.WriteString(cStr)
-	pb.rowLen += cLen
+	e.w.WriteString(cStr)
+	e.rowLen += cLen
+}
+
+type p6Encoder struct {
+	w *bufio.Writer
+}
+
+// WriteHeader writes the image header.
+func (e *p6Encoder) WriteHeader(width, height int) {
+	fmt.Fprintf(e.w, "P6\n%d %d\n255\n", width, height)
 }
 
-func (pb *ppmBuilder) String() string {
-	return pb.data.String()
+// WriteNewRow does nothing, because the binary pixel data isn't split into lines.
+func (e *p6Encoder) WriteNewRow() {}
+
+func (e *p6Encoder) WriteColor(c color.Color) {
+	e.w.WriteByte(byte(convertColorChannel(c.Red())))
+	e.w.WriteByte(byte(convertColorChannel(c.Green())))
+	e.w.WriteByte(byte(convertColorChannel(c.Blue())))
+}
+
+// saveFile creates the file with the given name and writes the image to it.
+func saveFile(filename string, w io.WriterTo) error {
+	f, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+
+	if _, err := w.WriteTo(f); err != nil {
+		f.Close()
+		return err
+	}
+
+	return f.Close()
+}
+
+// countingWriter counts the bytes written to the underlying writer.
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (cw *countingWriter) Write(p []byte) (int, error) {
+	n, err := cw.w.Write(p)
+	cw.n += int64(n)
+
+	return n, err
 }
 
 func convertColorChannel(c float64) int {
-	i := int(math.Ceil(c * 255))
+	return convertChannel(c, 255)
+}
+
+// convertChannel scales the color channel from the range 0..1 to 0..max, clamping values out of range.
+func convertChannel(c float64, max int) int {
+	i := int(math.Ceil(c * float64(max)))
 
 	if i < 0 {
 		i = 0
 	}
 
-	if i > 255 {
-		i = 255
+	if i > max {
+		i = max
 	}
 
 	return i
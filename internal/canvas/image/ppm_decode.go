@@ -0,0 +1,229 @@
+package image
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+
+	"github.com/tyz910/ray-tracer-challenge/internal/canvas"
+	"github.com/tyz910/ray-tracer-challenge/internal/canvas/color"
+)
+
+const (
+	maxPPMValue = 65535
+	// maxPixels limits the number of pixels of decoded images, so that broken headers don't allocate huge canvases
+	maxPixels = 1 << 26
+)
+
+// LoadPPM reads the PPM image file with the given name.
+func LoadPPM(filename string) (canvas.Canvas, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return canvas.Canvas{}, err
+	}
+	defer f.Close()
+
+	return DecodePPM(f)
+}
+
+// DecodePPM reads an image in the plain P3 or the raw P6 format. Comments are allowed anywhere
+// in the header and between the values of the P3 format. The colors are scaled from the maximum value
+// of the image to the range 0..1.
+func DecodePPM(r io.Reader) (canvas.Canvas, error) {
+	d := &ppmDecoder{r: bufio.NewReader(r)}
+
+	magic, err := d.readToken()
+	if err != nil {
+		return canvas.Canvas{}, fmt.Errorf("ppm: reading magic number: %v", err)
+	}
+
+	if magic != "P3" && magic != "P6" {
+		return canvas.Canvas{}, fmt.Errorf("ppm: unsupported magic number %q", magic)
+	}
+
+	width, err := d.readInt("width", 1, maxPixels)
+	if err != nil {
+		return canvas.Canvas{}, fmt.Errorf("ppm: %v", err)
+	}
+
+	height, err := d.readInt("height", 1, maxPixels)
+	if err != nil {
+		return canvas.Canvas{}, fmt.Errorf("ppm: %v", err)
+	}
+
+	if err := checkSize(width, height); err != nil {
+		return canvas.Canvas{}, fmt.Errorf("ppm: %v", err)
+	}
+
+	maxValue, err := d.readInt("maximum value", 1, maxPPMValue)
+	if err != nil {
+		return canvas.Canvas{}, fmt.Errorf("ppm: %v", err)
+	}
+
+	cnv := canvas.New(width, height)
+
+	readChannel := d.readPlainChannel
+	if magic == "P6" {
+		readChannel = d.readRawChannel
+		if maxValue > 255 {
+			readChannel = d.readRawChannel16
+		}
+	}
+
+	scale := 1.0 / float64(maxValue)
+
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			var rgb [3]int
+			for i := range rgb {
+				v, err := readChannel(maxValue)
+				if err != nil {
+					return canvas.Canvas{}, fmt.Errorf("ppm: pixel (%d, %d): %v", x, y, err)
+				}
+
+				rgb[i] = v
+			}
+
+			cnv.SetPixel(x, y, color.New(
+				float64(rgb[0])*scale,
+				float64(rgb[1])*scale,
+				float64(rgb[2])*scale,
+			))
+		}
+	}
+
+	return cnv, nil
+}
+
+type ppmDecoder struct {
+	r *bufio.Reader
+}
+
+// readToken returns the next whitespace separated token, skipping comments. A single whitespace
+// character following the token is consumed, so the raw pixel data starts right after the header.
+func (d *ppmDecoder) readToken() (string, error) {
+	var token []byte
+
+	for {
+		c, err := d.r.ReadByte()
+		if err == io.EOF && len(token) > 0 {
+			return string(token), nil
+		}
+
+		if err != nil {
+			return "", unexpectedEOF(err)
+		}
+
+		switch {
+		case c == '#':
+			if err := d.skipComment(); err != nil && len(token) == 0 {
+				return "", unexpectedEOF(err)
+			}
+
+			if len(token) > 0 {
+				return string(token), nil
+			}
+		case isSpace(c):
+			if len(token) > 0 {
+				return string(token), nil
+			}
+		default:
+			token = append(token, c)
+		}
+	}
+}
+
+func (d *ppmDecoder) skipComment() error {
+	for {
+		c, err := d.r.ReadByte()
+		if err != nil {
+			return err
+		}
+
+		if c == '\n' || c == '\r' {
+			return nil
+		}
+	}
+}
+
+func (d *ppmDecoder) readInt(name string, min, max int) (int, error) {
+	token, err := d.readToken()
+	if err != nil {
+		return 0, fmt.Errorf("reading %s: %v", name, err)
+	}
+
+	v, err := strconv.Atoi(token)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s %q", name, token)
+	}
+
+	if v < min || v > max {
+		return 0, fmt.Errorf("%s %d is out of range [%d, %d]", name, v, min, max)
+	}
+
+	return v, nil
+}
+
+func (d *ppmDecoder) readPlainChannel(maxValue int) (int, error) {
+	token, err := d.readToken()
+	if err != nil {
+		return 0, err
+	}
+
+	v, err := strconv.Atoi(token)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value %q", token)
+	}
+
+	return checkChannel(v, maxValue)
+}
+
+func (d *ppmDecoder) readRawChannel(maxValue int) (int, error) {
+	c, err := d.r.ReadByte()
+	if err != nil {
+		return 0, unexpectedEOF(err)
+	}
+
+	return checkChannel(int(c), maxValue)
+}
+
+// readRawChannel16 reads the two byte big-endian value used by images with the maximum value above 255.
+func (d *ppmDecoder) readRawChannel16(maxValue int) (int, error) {
+	var b [2]byte
+	if _, err := io.ReadFull(d.r, b[:]); err != nil {
+		return 0, unexpectedEOF(err)
+	}
+
+	return checkChannel(int(b[0])<<8|int(b[1]), maxValue)
+}
+
+// checkSize checks that the image with the given dimensions has no more than maxPixels pixels.
+func checkSize(width, height int) error {
+	if width*height > maxPixels {
+		return fmt.Errorf("image size %dx%d exceeds the limit of %d pixels", width, height, maxPixels)
+	}
+
+	return nil
+}
+
+func checkChannel(v, maxValue int) (int, error) {
+	if v < 0 || v > maxValue {
+		return 0, fmt.Errorf("value %d is out of range [0, %d]", v, maxValue)
+	}
+
+	return v, nil
+}
+
+func unexpectedEOF(err error) error {
+	if err == io.EOF {
+		return io.ErrUnexpectedEOF
+	}
+
+	return err
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == '\v' || c == '\f'
+}
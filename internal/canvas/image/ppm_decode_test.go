@@ -0,0 +1,206 @@
+package image_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tyz910/ray-tracer-challenge/internal/canvas"
+	"github.com/tyz910/ray-tracer-challenge/internal/canvas/color"
+	"github.com/tyz910/ray-tracer-challenge/internal/canvas/image"
+)
+
+// Reading a file with the wrong magic number
+func TestDecodeWrongMagic(t *testing.T) {
+	// Given
+	ppm := `P32
+1 1
+255
+0 0 0`
+
+	// When
+	_, err := image.DecodePPM(strings.NewReader(ppm))
+
+	// Then
+	assert.Error(t, err)
+}
+
+// Reading a PPM returns a canvas of the right size
+func TestDecodeSize(t *testing.T) {
+	// Given
+	ppm := `P3
+10 2
+255
+0 0 0  0 0 0  0 0 0  0 0 0  0 0 0
+0 0 0  0 0 0  0 0 0  0 0 0  0 0 0
+0 0 0  0 0 0  0 0 0  0 0 0  0 0 0
+0 0 0  0 0 0  0 0 0  0 0 0  0 0 0
+`
+
+	// When
+	c, err := image.DecodePPM(strings.NewReader(ppm))
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, 10, c.Width())
+	assert.Equal(t, 2, c.Height())
+}
+
+// Reading pixel data from a PPM file
+func TestDecodePixelData(t *testing.T) {
+	// Given
+	ppm := `P3
+4 3
+255
+255 127 0  0 127 255  127 255 0  255 255 255
+0 0 0  255 0 0  0 255 0  0 0 255
+255 255 0  0 255 255  255 0 255  127 127 127
+`
+
+	tests := []struct {
+		X     int
+		Y     int
+		Color color.Color
+	}{
+		{X: 0, Y: 0, Color: color.New(1.0, 0.49804, 0.0)},
+		{X: 1, Y: 0, Color: color.New(0.0, 0.49804, 1.0)},
+		{X: 2, Y: 0, Color: color.New(0.49804, 1.0, 0.0)},
+		{X: 3, Y: 0, Color: color.New(1.0, 1.0, 1.0)},
+		{X: 0, Y: 1, Color: color.New(0.0, 0.0, 0.0)},
+		{X: 1, Y: 1, Color: color.New(1.0, 0.0, 0.0)},
+		{X: 2, Y: 1, Color: color.New(0.0, 1.0, 0.0)},
+		{X: 3, Y: 1, Color: color.New(0.0, 0.0, 1.0)},
+		{X: 0, Y: 2, Color: color.New(1.0, 1.0, 0.0)},
+		{X: 1, Y: 2, Color: color.New(0.0, 1.0, 1.0)},
+		{X: 2, Y: 2, Color: color.New(1.0, 0.0, 1.0)},
+		{X: 3, Y: 2, Color: color.New(0.49804, 0.49804, 0.49804)},
+	}
+
+	// When
+	c, err := image.DecodePPM(strings.NewReader(ppm))
+	require.NoError(t, err)
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("Pixel (%d, %d)", test.X, test.Y), func(t *testing.T) {
+			// Then
+			assert.True(t, c.Pixel(test.X, test.Y).Equal(test.Color))
+		})
+	}
+}
+
+// PPM parsing ignores comment lines
+func TestDecodeComments(t *testing.T) {
+	// Given
+	ppm := `P3
+# this is a comment
+2 1
+# this, too
+255
+# another comment
+255 255 255
+# oh, no, comments in the pixel data!
+255 0 255
+`
+
+	// When
+	c, err := image.DecodePPM(strings.NewReader(ppm))
+
+	// Then
+	require.NoError(t, err)
+	assert.True(t, c.Pixel(0, 0).Equal(color.New(1.0, 1.0, 1.0)))
+	assert.True(t, c.Pixel(1, 0).Equal(color.New(1.0, 0.0, 1.0)))
+}
+
+// PPM parsing allows an RGB triple to span lines
+func TestDecodeSpanLines(t *testing.T) {
+	// Given
+	ppm := "P3 1 1\t255\r\n51\n153\n\n204\n"
+
+	// When
+	c, err := image.DecodePPM(strings.NewReader(ppm))
+
+	// Then
+	require.NoError(t, err)
+	assert.True(t, c.Pixel(0, 0).Equal(color.New(0.2, 0.6, 0.8)))
+}
+
+// PPM parsing respects the scale setting
+func TestDecodeMaxValue(t *testing.T) {
+	// Given
+	ppm := `P3
+2 2
+100
+100 100 100  50 50 50
+75 50 25  0 0 0
+`
+
+	// When
+	c, err := image.DecodePPM(strings.NewReader(ppm))
+
+	// Then
+	require.NoError(t, err)
+	assert.True(t, c.Pixel(0, 1).Equal(color.New(0.75, 0.5, 0.25)))
+}
+
+// Reading a binary P6 image written by the encoder
+func TestDecodeP6(t *testing.T) {
+	// Given
+	c := canvas.New(3, 2)
+	c.SetPixel(0, 0, color.New(1.0, 0.0, 0.0))
+	c.SetPixel(1, 0, color.New(0.0, 0.2, 0.0))
+	c.SetPixel(2, 1, color.New(0.2, 0.4, 1.0))
+	ppm := image.NewPPM(c)
+	ppm.SetFormat(image.P6)
+
+	// When
+	decoded, err := image.DecodePPM(strings.NewReader(ppm.String()))
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, c, decoded)
+}
+
+// Reading a binary P6 image with two bytes per value
+func TestDecodeP6Wide(t *testing.T) {
+	// Given
+	ppm := "P6 1 1 # comment after the header\n65535\n" + string([]byte{0xff, 0xff, 0x80, 0x00, 0x00, 0x00})
+
+	// When
+	c, err := image.DecodePPM(strings.NewReader(ppm))
+
+	// Then
+	require.NoError(t, err)
+	assert.True(t, c.Pixel(0, 0).Equal(color.New(1.0, 0.50001, 0.0)))
+}
+
+// Reading malformed PPM files
+func TestDecodeMalformed(t *testing.T) {
+	tests := []struct {
+		Name string
+		PPM  string
+	}{
+		{Name: "empty file", PPM: ""},
+		{Name: "missing header", PPM: "P3\n2\n"},
+		{Name: "invalid width", PPM: "P3\nwide 1\n255\n0 0 0\n"},
+		{Name: "zero height", PPM: "P3\n1 0\n255\n"},
+		{Name: "too many pixels", PPM: "P3\n10000 10000\n255\n0 0 0\n"},
+		{Name: "invalid maximum value", PPM: "P3\n1 1\n70000\n0 0 0\n"},
+		{Name: "missing pixels", PPM: "P3\n2 1\n255\n0 0 0\n"},
+		{Name: "invalid value", PPM: "P3\n1 1\n255\n0 zero 0\n"},
+		{Name: "value above maximum", PPM: "P3\n1 1\n100\n0 101 0\n"},
+		{Name: "truncated binary data", PPM: "P6\n2 1\n255\n\x00\x00\x00\x00"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			// When
+			_, err := image.DecodePPM(strings.NewReader(test.PPM))
+
+			// Then
+			assert.Error(t, err)
+		})
+	}
+}
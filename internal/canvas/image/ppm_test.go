@@ -1,10 +1,13 @@
 package image_test
 
 import (
+	"bytes"
+	"errors"
 	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/tyz910/ray-tracer-challenge/internal/canvas"
 	"github.com/tyz910/ray-tracer-challenge/internal/canvas/color"
@@ -85,3 +88,82 @@ func TestFileTerminate(t *testing.T) {
 	// Then
 	assert.Equal(t, "\n", ppm[len(ppm)-1:])
 }
+
+// Writing long rows on single lines
+func TestNoLineWrap(t *testing.T) {
+	// Given
+	c := canvas.New(10, 2)
+	for x := 0; x < c.Width(); x++ {
+		for y := 0; y < c.Height(); y++ {
+			c.SetPixel(x, y, color.New(1.0, 0.8, 0.6))
+		}
+	}
+
+	// When
+	ppm := image.NewPPM(c)
+	ppm.SetLineWrap(false)
+
+	// Then
+	lines := strings.Split(ppm.String(), "\n")
+	assert.False(t, ppm.LineWrap())
+	assert.Equal(t, []string{
+		strings.TrimSpace(strings.Repeat("255 204 153 ", 10)),
+		strings.TrimSpace(strings.Repeat("255 204 153 ", 10)),
+		"",
+	}, lines[3:])
+}
+
+// Streaming the PPM image to a writer
+func TestWriteTo(t *testing.T) {
+	// Given
+	c := canvas.New(5, 3)
+	c.SetPixel(2, 1, color.New(0.0, 0.5, 0.0))
+	ppm := image.NewPPM(c)
+	var buf bytes.Buffer
+
+	// When
+	n, err := ppm.WriteTo(&buf)
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, int64(buf.Len()), n)
+	assert.Equal(t, ppm.String(), buf.String())
+}
+
+// Constructing the binary P6 image
+func TestP6(t *testing.T) {
+	// Given
+	c := canvas.New(2, 2)
+	c.SetPixel(0, 0, color.New(1.5, 0.0, 0.0))
+	c.SetPixel(1, 0, color.New(0.0, 0.5, 0.0))
+	c.SetPixel(1, 1, color.New(-0.5, 0.0, 1.0))
+
+	// When
+	ppm := image.NewPPM(c)
+	ppm.SetFormat(image.P6)
+
+	// Then
+	assert.Equal(t, image.P6, ppm.Format())
+	assert.Equal(t, "P6\n2 2\n255\n"+string([]byte{
+		255, 0, 0, 0, 128, 0,
+		0, 0, 0, 0, 0, 255,
+	}), ppm.String())
+}
+
+// Reporting errors of the writer
+func TestWriteToError(t *testing.T) {
+	// Given
+	ppm := image.NewPPM(canvas.New(5, 3))
+
+	// When
+	_, err := ppm.WriteTo(failingWriter{})
+
+	// Then
+	assert.Error(t, err)
+}
+
+type failingWriter struct{}
+
+func (failingWriter) Write(_ []byte) (int, error) {
+	return 0, errors.New("disk is full")
+}
@@ -0,0 +1,169 @@
+package matrix
+
+import (
+	"fmt"
+
+	"github.com/tyz910/ray-tracer-challenge/internal/math"
+	"github.com/tyz910/ray-tracer-challenge/internal/math/tuple"
+)
+
+// Mat4 is a 4x4 matrix stored by value in row-major order.
+// Unlike Matrix, its operations never allocate, so it is used for all transformations.
+type Mat4 [16]float64
+
+// Identity4 creates new 4x4 identity matrix.
+func Identity4() Mat4 {
+	return Mat4{
+		1.0, 0.0, 0.0, 0.0,
+		0.0, 1.0, 0.0, 0.0,
+		0.0, 0.0, 1.0, 0.0,
+		0.0, 0.0, 0.0, 1.0,
+	}
+}
+
+// FromMatrix converts 4x4 matrix to Mat4.
+func FromMatrix(m Matrix) Mat4 {
+	if m.rows != 4 || m.columns != 4 {
+		panic(fmt.Sprintf("invalid matrix shape %dx%d for conversion to 4x4 matrix", m.rows, m.columns))
+	}
+
+	var result Mat4
+	copy(result[:], m.values)
+
+	return result
+}
+
+// Matrix converts the matrix to generic Matrix.
+func (m Mat4) Matrix() Matrix {
+	values := make([]float64, 16)
+	copy(values, m[:])
+
+	return New(4, 4, values)
+}
+
+// Value returns the value at position (row, column).
+func (m Mat4) Value(row, column int) float64 {
+	return m[row*4+column]
+}
+
+// Equal approximately compares two matrices.
+func (m Mat4) Equal(m2 Mat4) bool {
+	for i := range m {
+		if !math.Equals(m[i], m2[i]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// MatMul multiplies the matrix by a matrix.
+func (m Mat4) MatMul(m2 Mat4) Mat4 {
+	var result Mat4
+	for row := 0; row < 4; row++ {
+		for col := 0; col < 4; col++ {
+			result[row*4+col] = m[row*4]*m2[col] +
+				m[row*4+1]*m2[4+col] +
+				m[row*4+2]*m2[8+col] +
+				m[row*4+3]*m2[12+col]
+		}
+	}
+
+	return result
+}
+
+// TupMul multiplies the matrix by a tuple.
+func (m Mat4) TupMul(t tuple.Tuple) tuple.Tuple {
+	x, y, z, w := t.X(), t.Y(), t.Z(), t.W()
+
+	return tuple.New(
+		m[0]*x+m[1]*y+m[2]*z+m[3]*w,
+		m[4]*x+m[5]*y+m[6]*z+m[7]*w,
+		m[8]*x+m[9]*y+m[10]*z+m[11]*w,
+		m[12]*x+m[13]*y+m[14]*z+m[15]*w,
+	)
+}
+
+// Transpose transposes the matrix by turning it's rows into columns and it's columns into rows.
+func (m Mat4) Transpose() Mat4 {
+	return Mat4{
+		m[0], m[4], m[8], m[12],
+		m[1], m[5], m[9], m[13],
+		m[2], m[6], m[10], m[14],
+		m[3], m[7], m[11], m[15],
+	}
+}
+
+// minors2x2 returns the determinants of the 2x2 submatrices formed by the two upper rows (s)
+// and by the two lower rows (c), which are shared by the determinant and the inverse.
+func (m Mat4) minors2x2() (s, c [6]float64) {
+	s[0] = m[0]*m[5] - m[4]*m[1]
+	s[1] = m[0]*m[6] - m[4]*m[2]
+	s[2] = m[0]*m[7] - m[4]*m[3]
+	s[3] = m[1]*m[6] - m[5]*m[2]
+	s[4] = m[1]*m[7] - m[5]*m[3]
+	s[5] = m[2]*m[7] - m[6]*m[3]
+
+	c[5] = m[10]*m[15] - m[14]*m[11]
+	c[4] = m[9]*m[15] - m[13]*m[11]
+	c[3] = m[9]*m[14] - m[13]*m[10]
+	c[2] = m[8]*m[15] - m[12]*m[11]
+	c[1] = m[8]*m[14] - m[12]*m[10]
+	c[0] = m[8]*m[13] - m[12]*m[9]
+
+	return
+}
+
+// Determinant returns the determinant of the matrix.
+func (m Mat4) Determinant() float64 {
+	s, c := m.minors2x2()
+
+	return s[0]*c[5] - s[1]*c[4] + s[2]*c[3] + s[3]*c[2] - s[4]*c[1] + s[5]*c[0]
+}
+
+// IsInvertible tests the matrix for invertibility.
+func (m Mat4) IsInvertible() bool {
+	return m.Determinant() != 0
+}
+
+// Inverse returns the inverse of the matrix, computed in closed form by the Laplace expansion.
+func (m Mat4) Inverse() Mat4 {
+	s, c := m.minors2x2()
+
+	det := s[0]*c[5] - s[1]*c[4] + s[2]*c[3] + s[3]*c[2] - s[4]*c[1] + s[5]*c[0]
+	if det == 0 {
+		panic("matrix is not invertible")
+	}
+
+	inv := 1.0 / det
+
+	r := Mat4{
+		(m[5]*c[5] - m[6]*c[4] + m[7]*c[3]) * inv,
+		(-m[1]*c[5] + m[2]*c[4] - m[3]*c[3]) * inv,
+		(m[13]*s[5] - m[14]*s[4] + m[15]*s[3]) * inv,
+		(-m[9]*s[5] + m[10]*s[4] - m[11]*s[3]) * inv,
+
+		(-m[4]*c[5] + m[6]*c[2] - m[7]*c[1]) * inv,
+		(m[0]*c[5] - m[2]*c[2] + m[3]*c[1]) * inv,
+		(-m[12]*s[5] + m[14]*s[2] - m[15]*s[1]) * inv,
+		(m[8]*s[5] - m[10]*s[2] + m[11]*s[1]) * inv,
+
+		(m[4]*c[4] - m[5]*c[2] + m[7]*c[0]) * inv,
+		(-m[0]*c[4] + m[1]*c[2] - m[3]*c[0]) * inv,
+		(m[12]*s[4] - m[13]*s[2] + m[15]*s[0]) * inv,
+		(-m[8]*s[4] + m[9]*s[2] - m[11]*s[0]) * inv,
+
+		(-m[4]*c[3] + m[5]*c[1] - m[6]*c[0]) * inv,
+		(m[0]*c[3] - m[1]*c[1] + m[2]*c[0]) * inv,
+		(-m[12]*s[3] + m[13]*s[1] - m[14]*s[0]) * inv,
+		(m[8]*s[3] - m[9]*s[1] + m[10]*s[0]) * inv,
+	}
+
+	// the inverse of an affine transformation is affine too, so its last row is set exactly
+	// to keep the w component of transformed points and vectors free of rounding errors
+	if m[12] == 0.0 && m[13] == 0.0 && m[14] == 0.0 && m[15] == 1.0 {
+		r[12], r[13], r[14], r[15] = 0.0, 0.0, 0.0, 1.0
+	}
+
+	return r
+}
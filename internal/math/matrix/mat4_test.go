@@ -0,0 +1,292 @@
+package matrix_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/tyz910/ray-tracer-challenge/internal/math/matrix"
+	"github.com/tyz910/ray-tracer-challenge/internal/math/tuple"
+)
+
+// Converting between 4x4 matrix representations
+func TestMat4FromMatrix(t *testing.T) {
+	// Given
+	m := matrix.New(4, 4, []float64{
+		1.0, 2.0, 3.0, 4.0,
+		5.5, 6.5, 7.5, 8.5,
+		9.0, 10.0, 11.0, 12.0,
+		13.5, 14.5, 15.5, 16.5,
+	})
+
+	// When
+	m4 := matrix.FromMatrix(m)
+
+	// Then
+	assert.Equal(t, 1.0, m4.Value(0, 0))
+	assert.Equal(t, 4.0, m4.Value(0, 3))
+	assert.Equal(t, 5.5, m4.Value(1, 0))
+	assert.Equal(t, 7.5, m4.Value(1, 2))
+	assert.Equal(t, 11.0, m4.Value(2, 2))
+	assert.Equal(t, 13.5, m4.Value(3, 0))
+	assert.Equal(t, 15.5, m4.Value(3, 2))
+	assert.Equal(t, m, m4.Matrix())
+}
+
+// Only 4x4 matrices can be converted
+func TestMat4FromMatrixInvalid(t *testing.T) {
+	assert.Panics(t, func() {
+		matrix.FromMatrix(matrix.New(3, 3, nil))
+	})
+}
+
+// Multiplying two matrices
+func TestMat4MatMul(t *testing.T) {
+	// Given
+	a := matrix.Mat4{
+		1.0, 2.0, 3.0, 4.0,
+		5.0, 6.0, 7.0, 8.0,
+		9.0, 8.0, 7.0, 6.0,
+		5.0, 4.0, 3.0, 2.0,
+	}
+
+	b := matrix.Mat4{
+		-2.0, 1.0, 2.0, 3.0,
+		3.0, 2.0, 1.0, -1.0,
+		4.0, 3.0, 6.0, 5.0,
+		1.0, 2.0, 7.0, 8.0,
+	}
+
+	// Then
+	assert.Equal(t, matrix.Mat4{
+		20.0, 22.0, 50.0, 48.0,
+		44.0, 54.0, 114.0, 108.0,
+		40.0, 58.0, 110.0, 102.0,
+		16.0, 26.0, 46.0, 42.0,
+	}, a.MatMul(b))
+	assert.Equal(t, a, a.MatMul(matrix.Identity4()))
+}
+
+// A matrix multiplied by a tuple
+func TestMat4TupMul(t *testing.T) {
+	// Given
+	a := matrix.Mat4{
+		1.0, 2.0, 3.0, 4.0,
+		2.0, 4.0, 4.0, 2.0,
+		8.0, 6.0, 4.0, 1.0,
+		0.0, 0.0, 0.0, 1.0,
+	}
+	b := tuple.New(1.0, 2.0, 3.0, 1.0)
+
+	// Then
+	assert.Equal(t, tuple.New(18.0, 24.0, 33.0, 1.0), a.TupMul(b))
+	assert.Equal(t, b, matrix.Identity4().TupMul(b))
+}
+
+// Transposing a matrix
+func TestMat4Transpose(t *testing.T) {
+	// Given
+	a := matrix.Mat4{
+		0.0, 9.0, 3.0, 0.0,
+		9.0, 8.0, 0.0, 8.0,
+		1.0, 8.0, 5.0, 3.0,
+		0.0, 0.0, 5.0, 8.0,
+	}
+
+	// Then
+	assert.Equal(t, matrix.Mat4{
+		0.0, 9.0, 1.0, 0.0,
+		9.0, 8.0, 8.0, 0.0,
+		3.0, 0.0, 5.0, 5.0,
+		0.0, 8.0, 3.0, 8.0,
+	}, a.Transpose())
+	assert.Equal(t, matrix.Identity4(), matrix.Identity4().Transpose())
+}
+
+// Calculating the determinant of a 4x4 matrix
+func TestMat4Determinant(t *testing.T) {
+	tests := []struct {
+		A           matrix.Mat4
+		Determinant float64
+		Invertible  bool
+	}{
+		{
+			A: matrix.Mat4{
+				-2.0, -8.0, 3.0, 5.0,
+				-3.0, 1.0, 7.0, 3.0,
+				1.0, 2.0, -9.0, 6.0,
+				-6.0, 7.0, 7.0, -9.0,
+			},
+			Determinant: -4071.0,
+			Invertible:  true,
+		},
+
+		{
+			A: matrix.Mat4{
+				6.0, 4.0, 4.0, 4.0,
+				5.0, 5.0, 7.0, 6.0,
+				4.0, -9.0, 3.0, -7.0,
+				9.0, 1.0, 7.0, -6.0,
+			},
+			Determinant: -2120.0,
+			Invertible:  true,
+		},
+
+		{
+			A: matrix.Mat4{
+				-4.0, 2.0, -2.0, -3.0,
+				9.0, 6.0, 2.0, 6.0,
+				0.0, -5.0, 1.0, -5.0,
+				0.0, 0.0, 0.0, 0.0,
+			},
+			Determinant: 0.0,
+			Invertible:  false,
+		},
+	}
+
+	for i, test := range tests {
+		t.Run(fmt.Sprintf("Calculating the determinant of a 4x4 matrix #%d", i), func(t *testing.T) {
+			assert.Equal(t, test.Determinant, test.A.Determinant())
+			assert.Equal(t, test.Invertible, test.A.IsInvertible())
+			assert.Equal(t, test.A.Matrix().Determinant(), test.A.Determinant())
+		})
+	}
+}
+
+// Calculating the inverse of a matrix
+func TestMat4Inverse(t *testing.T) {
+	tests := []struct {
+		A matrix.Mat4
+		B matrix.Mat4
+	}{
+		{
+			A: matrix.Mat4{
+				-5.0, 2.0, 6.0, -8.0,
+				1.0, -5.0, 1.0, 8.0,
+				7.0, 7.0, -6.0, -7.0,
+				1.0, -3.0, 7.0, 4.0,
+			},
+			B: matrix.Mat4{
+				0.21805, 0.45113, 0.24060, -0.04511,
+				-0.80827, -1.45677, -0.44361, 0.52068,
+				-0.07895, -0.22368, -0.05263, 0.19737,
+				-0.52256, -0.81391, -0.30075, 0.30639,
+			},
+		},
+
+		{
+			A: matrix.Mat4{
+				8.0, -5.0, 9.0, 2.0,
+				7.0, 5.0, 6.0, 1.0,
+				-6.0, 0.0, 9.0, 6.0,
+				-3.0, 0.0, -9.0, -4.0,
+			},
+			B: matrix.Mat4{
+				-0.15385, -0.15385, -0.28205, -0.53846,
+				-0.07692, 0.12308, 0.02564, 0.03077,
+				0.35897, 0.35897, 0.43590, 0.92308,
+				-0.69231, -0.69231, -0.76923, -1.92308,
+			},
+		},
+
+		{
+			A: matrix.Mat4{
+				9.0, 3.0, 0.0, 9.0,
+				-5.0, -2.0, -6.0, -3.0,
+				-4.0, 9.0, 6.0, 4.0,
+				-7.0, 6.0, 6.0, 2.0,
+			},
+			B: matrix.Mat4{
+				-0.04074, -0.07778, 0.14444, -0.22222,
+				-0.07778, 0.03333, 0.36667, -0.33333,
+				-0.02901, -0.14630, -0.10926, 0.12963,
+				0.17778, 0.06667, -0.26667, 0.33333,
+			},
+		},
+	}
+
+	for i, test := range tests {
+		t.Run(fmt.Sprintf("Calculating the inverse of a matrix #%d", i), func(t *testing.T) {
+			// Given
+			a := test.A
+
+			// Then
+			assert.True(t, a.Inverse().Equal(test.B))
+			assert.True(t, a.Inverse().Matrix().Equal(a.Matrix().Inverse()))
+			assert.True(t, a.MatMul(a.Inverse()).Equal(matrix.Identity4()))
+		})
+	}
+}
+
+// Inverting a noninvertible matrix
+// Inverting an affine transformation keeps points and vectors exact
+func TestMat4InverseAffine(t *testing.T) {
+	// Given
+	from := tuple.Point(0.0, 1.5, -5.0)
+	to := tuple.Point(0.0, 1.0, 0.0)
+	up := tuple.Vector(0.0, 1.0, 0.0)
+	m := matrix.ViewTransform(from, to, up).Inverse()
+
+	// When
+	p := m.TupMul(tuple.Point(0.0, 0.0, 0.0))
+	v := m.TupMul(tuple.Vector(0.0, 0.0, -1.0))
+
+	// Then
+	assert.True(t, p.IsPoint())
+	assert.True(t, v.IsVector())
+	assert.True(t, p.Equal(from))
+}
+
+func TestMat4InverseNonInvertible(t *testing.T) {
+	// Given
+	a := matrix.Mat4{
+		-4.0, 2.0, -2.0, -3.0,
+		9.0, 6.0, 2.0, 6.0,
+		0.0, -5.0, 1.0, -5.0,
+		0.0, 0.0, 0.0, 0.0,
+	}
+
+	// Then
+	assert.Panics(t, func() {
+		a.Inverse()
+	})
+}
+
+func BenchmarkMatrixInverse(b *testing.B) {
+	m := matrix.Translation(1.0, 2.0, 3.0).MatMul(matrix.RotationY(0.5)).Matrix()
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		m.Inverse()
+	}
+}
+
+func BenchmarkMat4Inverse(b *testing.B) {
+	m := matrix.Translation(1.0, 2.0, 3.0).MatMul(matrix.RotationY(0.5))
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		m.Inverse()
+	}
+}
+
+func BenchmarkMatrixTupMul(b *testing.B) {
+	m := matrix.Translation(1.0, 2.0, 3.0).Matrix()
+	p := tuple.Point(1.0, 2.0, 3.0)
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		m.TupMul(p)
+	}
+}
+
+func BenchmarkMat4TupMul(b *testing.B) {
+	m := matrix.Translation(1.0, 2.0, 3.0)
+	p := tuple.Point(1.0, 2.0, 3.0)
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		m.TupMul(p)
+	}
+}
@@ -241,3 +241,61 @@ func TestTransformChained(t *testing.T) {
 	// Then
 	assert.True(t, transform.TupMul(p).Equal(tuple.Point(15.0, 0.0, 7.0)))
 }
+
+// The view transformation
+func TestViewTransform(t *testing.T) {
+	tests := []struct {
+		Name     string
+		From     tuple.Tuple
+		To       tuple.Tuple
+		Up       tuple.Tuple
+		Expected matrix.Mat4
+	}{
+		{
+			Name:     "The transformation matrix for the default orientation",
+			From:     tuple.Point(0.0, 0.0, 0.0),
+			To:       tuple.Point(0.0, 0.0, -1.0),
+			Up:       tuple.Vector(0.0, 1.0, 0.0),
+			Expected: matrix.Identity4(),
+		},
+
+		{
+			Name:     "A view transformation matrix looking in positive z direction",
+			From:     tuple.Point(0.0, 0.0, 0.0),
+			To:       tuple.Point(0.0, 0.0, 1.0),
+			Up:       tuple.Vector(0.0, 1.0, 0.0),
+			Expected: matrix.Scaling(-1.0, 1.0, -1.0),
+		},
+
+		{
+			Name:     "The view transformation moves the world",
+			From:     tuple.Point(0.0, 0.0, 8.0),
+			To:       tuple.Point(0.0, 0.0, 0.0),
+			Up:       tuple.Vector(0.0, 1.0, 0.0),
+			Expected: matrix.Translation(0.0, 0.0, -8.0),
+		},
+
+		{
+			Name: "An arbitrary view transformation",
+			From: tuple.Point(1.0, 3.0, 2.0),
+			To:   tuple.Point(4.0, -2.0, 8.0),
+			Up:   tuple.Vector(1.0, 1.0, 0.0),
+			Expected: matrix.Mat4{
+				-0.50709, 0.50709, 0.67612, -2.36643,
+				0.76772, 0.60609, 0.12122, -2.82843,
+				-0.35857, 0.59761, -0.71714, 0.00000,
+				0.00000, 0.00000, 0.00000, 1.00000,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			// When
+			transform := matrix.ViewTransform(test.From, test.To, test.Up)
+
+			// Then
+			assert.True(t, transform.Equal(test.Expected))
+		})
+	}
+}
@@ -0,0 +1,39 @@
+package noise_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/tyz910/ray-tracer-challenge/internal/math/noise"
+)
+
+// Noise is zero at the lattice points
+func TestPerlinLattice(t *testing.T) {
+	for x := -2.0; x <= 2.0; x++ {
+		for y := -2.0; y <= 2.0; y++ {
+			for z := -2.0; z <= 2.0; z++ {
+				assert.Equal(t, 0.0, noise.Perlin(x, y, z))
+			}
+		}
+	}
+}
+
+// Noise is bounded and continuous between the lattice points
+func TestPerlinRange(t *testing.T) {
+	prev := noise.Perlin(0.0, 0.5, 0.5)
+	for x := 0.0; x <= 4.0; x += 0.001 {
+		n := noise.Perlin(x, 0.5, 0.5)
+
+		assert.True(t, n >= -1.0 && n <= 1.0)
+		assert.True(t, math.Abs(n-prev) < 0.01)
+		prev = n
+	}
+}
+
+// Noise is deterministic
+func TestPerlinDeterministic(t *testing.T) {
+	assert.Equal(t, noise.Perlin(1.3, 2.7, -0.4), noise.Perlin(1.3, 2.7, -0.4))
+	assert.NotEqual(t, 0.0, noise.Perlin(1.3, 2.7, -0.4))
+}
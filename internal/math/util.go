@@ -4,9 +4,10 @@ import (
 	"math"
 )
 
-const epsilon = 0.00001
+// Epsilon is the tolerance used for floating point comparisons.
+const Epsilon = 0.00001
 
 // Equals approximately compares two floats.
 func Equals(a, b float64) bool {
-	return math.Abs(a-b) < epsilon
+	return math.Abs(a-b) < Epsilon
 }
@@ -0,0 +1,274 @@
+package camera
+
+import (
+	"fmt"
+	"math"
+	"runtime"
+	"sync"
+	"sync/atomic"
+
+	"github.com/tyz910/ray-tracer-challenge/internal/canvas"
+	"github.com/tyz910/ray-tracer-challenge/internal/canvas/color"
+	"github.com/tyz910/ray-tracer-challenge/internal/math/matrix"
+	"github.com/tyz910/ray-tracer-challenge/internal/math/tuple"
+	"github.com/tyz910/ray-tracer-challenge/internal/render"
+	"github.com/tyz910/ray-tracer-challenge/internal/render/ray"
+)
+
+// Camera maps the three-dimensional scene onto a two-dimensional canvas.
+// The canvas is always exactly one unit in front of the camera.
+type Camera struct {
+	hsize, vsize int
+	fieldOfView  float64
+	transform    matrix.Mat4
+	inverse      matrix.Mat4
+
+	halfWidth, halfHeight float64
+	pixelSize             float64
+
+	workers int
+	samples int
+
+	progress func(done, total int)
+}
+
+// New creates new camera with the given horizontal and vertical size of the canvas in pixels,
+// and the angle that describes how much the camera can see.
+func New(hsize, vsize int, fieldOfView float64) *Camera {
+	c := &Camera{
+		hsize:       hsize,
+		vsize:       vsize,
+		fieldOfView: fieldOfView,
+		transform:   matrix.Identity4(),
+		inverse:     matrix.Identity4(),
+		workers:     runtime.GOMAXPROCS(0),
+		samples:     1,
+	}
+
+	halfView := math.Tan(fieldOfView / 2.0)
+	aspect := float64(hsize) / float64(vsize)
+
+	if aspect >= 1.0 {
+		c.halfWidth = halfView
+		c.halfHeight = halfView / aspect
+	} else {
+		c.halfWidth = halfView * aspect
+		c.halfHeight = halfView
+	}
+
+	c.pixelSize = (c.halfWidth * 2.0) / float64(hsize)
+
+	return c
+}
+
+// HSize returns the horizontal size of the canvas in pixels.
+func (c *Camera) HSize() int {
+	return c.hsize
+}
+
+// VSize returns the vertical size of the canvas in pixels.
+func (c *Camera) VSize() int {
+	return c.vsize
+}
+
+// FieldOfView returns the angle that describes how much the camera can see.
+func (c *Camera) FieldOfView() float64 {
+	return c.fieldOfView
+}
+
+// PixelSize returns the size of a single pixel on the canvas in world space units.
+func (c *Camera) PixelSize() float64 {
+	return c.pixelSize
+}
+
+// Transform returns the view transformation matrix of the camera.
+func (c *Camera) Transform() matrix.Mat4 {
+	return c.transform
+}
+
+// SetTransform changes the view transformation matrix of the camera.
+func (c *Camera) SetTransform(m matrix.Mat4) {
+	c.transform = m
+	c.inverse = m.Inverse()
+}
+
+// Workers returns the number of goroutines rendering the image in parallel.
+func (c *Camera) Workers() int {
+	return c.workers
+}
+
+// SetWorkers changes the number of goroutines rendering the image in parallel.
+// Values less than one are treated as one, i.e. the image is rendered serially.
+func (c *Camera) SetWorkers(workers int) {
+	if workers < 1 {
+		workers = 1
+	}
+
+	c.workers = workers
+}
+
+// Samples returns the number of rays cast through every pixel.
+func (c *Camera) Samples() int {
+	return c.samples
+}
+
+// SetSamples changes the number of rays cast through every pixel. The color of the pixel is the average
+// of all samples, which smooths jagged edges. Values less than one are treated as one.
+func (c *Camera) SetSamples(samples int) {
+	if samples < 1 {
+		samples = 1
+	}
+
+	c.samples = samples
+}
+
+// SetProgress sets the function called after every rendered row with the number of rows done so far
+// and the total number of rows. The function is always called from the goroutine running Render.
+func (c *Camera) SetProgress(progress func(done, total int)) {
+	c.progress = progress
+}
+
+// RayForPixel returns a new ray that starts at the camera and passes through the center of the pixel (px, py) on the canvas.
+func (c *Camera) RayForPixel(px, py int) ray.Ray {
+	return c.RayForSample(px, py, 0.5, 0.5)
+}
+
+// RayForSample returns a new ray that starts at the camera and passes through the pixel (px, py) on the canvas
+// at the given offset from the pixel's top left corner. Both offsets are fractions of the pixel size in range [0, 1).
+func (c *Camera) RayForSample(px, py int, dx, dy float64) ray.Ray {
+	// the offset from the edge of the canvas to the sample point
+	xOffset := (float64(px) + dx) * c.pixelSize
+	yOffset := (float64(py) + dy) * c.pixelSize
+
+	// the untransformed coordinates of the pixel in world space
+	// (the camera looks toward -z, so +x is to the left)
+	worldX := c.halfWidth - xOffset
+	worldY := c.halfHeight - yOffset
+
+	// transform the canvas point and the origin (the canvas is at z=-1),
+	// and then compute the ray's direction vector
+	pixel := c.inverse.TupMul(tuple.Point(worldX, worldY, -1.0))
+	origin := c.inverse.TupMul(tuple.Point(0.0, 0.0, 0.0))
+	direction := pixel.Sub(origin).Normalize()
+
+	return ray.New(origin, direction)
+}
+
+// Render renders an image of the given world. The rows of the image are distributed among the workers,
+// each pixel is computed independently, so the result doesn't depend on the number of workers.
+// A panic in a worker, e.g. caused by a malformed world, stops the rendering and is returned as an error.
+func (c *Camera) Render(w *render.World) (canvas.Canvas, error) {
+	cnv := canvas.New(c.hsize, c.vsize)
+
+	rows := make(chan int, c.vsize)
+	for y := 0; y < c.vsize; y++ {
+		rows <- y
+	}
+	close(rows)
+
+	workers := c.workers
+	if workers > c.vsize {
+		workers = c.vsize
+	}
+
+	offsets := sampleOffsets(c.samples)
+	done := make(chan error, c.vsize)
+
+	// set after the first failure, so that the remaining rows are skipped
+	var failed int32
+
+	var wg sync.WaitGroup
+	wg.Add(workers)
+
+	for i := 0; i < workers; i++ {
+		go func() {
+			defer wg.Done()
+
+			// every row is written by a single worker, so no two workers touch the same pixel
+			for y := range rows {
+				if atomic.LoadInt32(&failed) != 0 {
+					done <- nil
+					continue
+				}
+
+				err := c.renderRow(w, cnv, y, offsets)
+				if err != nil {
+					atomic.StoreInt32(&failed, 1)
+				}
+
+				done <- err
+			}
+		}()
+	}
+
+	var err error
+	for rendered := 1; rendered <= c.vsize; rendered++ {
+		if rowErr := <-done; rowErr != nil && err == nil {
+			err = rowErr
+		}
+
+		if err == nil && c.progress != nil {
+			c.progress(rendered, c.vsize)
+		}
+	}
+
+	wg.Wait()
+
+	if err != nil {
+		return canvas.Canvas{}, err
+	}
+
+	return cnv, nil
+}
+
+// renderRow renders a single row of the image onto the canvas, averaging the samples at the given offsets.
+// A panic while rendering the row is recovered and returned as an error.
+func (c *Camera) renderRow(w *render.World, cnv canvas.Canvas, y int, offsets [][2]float64) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("rendering row %d: %v", y, r)
+		}
+	}()
+
+	for x := 0; x < c.hsize; x++ {
+		sum := color.Black()
+		for _, o := range offsets {
+			r := c.RayForSample(x, y, o[0], o[1])
+			sum = sum.Add(w.ColorAt(r, w.MaxDepth()))
+		}
+
+		cnv.SetPixel(x, y, sum.Mul(1.0/float64(len(offsets))))
+	}
+
+	return nil
+}
+
+// sampleOffsets returns the offsets of the samples within a pixel. A single sample is taken at the center,
+// multiple samples are spread evenly over the pixel using the Halton sequence with bases 2 and 3,
+// so the image stays deterministic.
+func sampleOffsets(samples int) [][2]float64 {
+	if samples == 1 {
+		return [][2]float64{{0.5, 0.5}}
+	}
+
+	offsets := make([][2]float64, samples)
+	for i := range offsets {
+		offsets[i] = [2]float64{halton(i+1, 2), halton(i+1, 3)}
+	}
+
+	return offsets
+}
+
+// halton returns the element of the Halton low-discrepancy sequence with the given index and base.
+func halton(index, base int) float64 {
+	result := 0.0
+	f := 1.0
+
+	for index > 0 {
+		f /= float64(base)
+		result += f * float64(index%base)
+		index /= base
+	}
+
+	return result
+}
@@ -0,0 +1,291 @@
+package camera_test
+
+import (
+	"fmt"
+	"math"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tyz910/ray-tracer-challenge/internal/canvas/color"
+	"github.com/tyz910/ray-tracer-challenge/internal/math/matrix"
+	"github.com/tyz910/ray-tracer-challenge/internal/math/tuple"
+	"github.com/tyz910/ray-tracer-challenge/internal/render"
+	"github.com/tyz910/ray-tracer-challenge/internal/render/camera"
+	"github.com/tyz910/ray-tracer-challenge/internal/render/ray"
+	"github.com/tyz910/ray-tracer-challenge/internal/render/shape"
+	"github.com/tyz910/ray-tracer-challenge/internal/render/shape/sphere"
+)
+
+// Constructing a camera
+func TestCreateCamera(t *testing.T) {
+	// Given
+	hsize := 160
+	vsize := 120
+	fieldOfView := math.Pi / 2.0
+
+	// When
+	c := camera.New(hsize, vsize, fieldOfView)
+
+	// Then
+	assert.Equal(t, 160, c.HSize())
+	assert.Equal(t, 120, c.VSize())
+	assert.Equal(t, math.Pi/2.0, c.FieldOfView())
+	assert.True(t, c.Transform().Equal(matrix.Identity4()))
+	assert.Equal(t, runtime.GOMAXPROCS(0), c.Workers())
+}
+
+// The pixel size for a horizontal canvas
+func TestPixelSizeHorizontal(t *testing.T) {
+	// Given
+	c := camera.New(200, 125, math.Pi/2.0)
+
+	// Then
+	assert.InDelta(t, 0.01, c.PixelSize(), 0.00001)
+}
+
+// The pixel size for a vertical canvas
+func TestPixelSizeVertical(t *testing.T) {
+	// Given
+	c := camera.New(125, 200, math.Pi/2.0)
+
+	// Then
+	assert.InDelta(t, 0.01, c.PixelSize(), 0.00001)
+}
+
+// Constructing a ray through the canvas
+func TestRayForPixel(t *testing.T) {
+	tests := []struct {
+		Name      string
+		Transform matrix.Mat4
+		X, Y      int
+		Origin    tuple.Tuple
+		Direction tuple.Tuple
+	}{
+		{
+			Name:      "Constructing a ray through the center of the canvas",
+			Transform: matrix.Identity4(),
+			X:         100,
+			Y:         50,
+			Origin:    tuple.Point(0.0, 0.0, 0.0),
+			Direction: tuple.Vector(0.0, 0.0, -1.0),
+		},
+
+		{
+			Name:      "Constructing a ray through a corner of the canvas",
+			Transform: matrix.Identity4(),
+			X:         0,
+			Y:         0,
+			Origin:    tuple.Point(0.0, 0.0, 0.0),
+			Direction: tuple.Vector(0.66519, 0.33259, -0.66851),
+		},
+
+		{
+			Name:      "Constructing a ray when the camera is transformed",
+			Transform: matrix.RotationY(math.Pi / 4.0).MatMul(matrix.Translation(0.0, -2.0, 5.0)),
+			X:         100,
+			Y:         50,
+			Origin:    tuple.Point(0.0, 2.0, -5.0),
+			Direction: tuple.Vector(math.Sqrt(2.0)/2.0, 0.0, -math.Sqrt(2.0)/2.0),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			// Given
+			c := camera.New(201, 101, math.Pi/2.0)
+			c.SetTransform(test.Transform)
+
+			// When
+			r := c.RayForPixel(test.X, test.Y)
+
+			// Then
+			assert.True(t, r.Origin().Equal(test.Origin))
+			assert.True(t, r.Direction().Equal(test.Direction))
+		})
+	}
+}
+
+// Rendering a world with a camera
+func TestRender(t *testing.T) {
+	// Given
+	w := render.DefaultWorld()
+	c := camera.New(11, 11, math.Pi/2.0)
+	from := tuple.Point(0.0, 0.0, -5.0)
+	to := tuple.Point(0.0, 0.0, 0.0)
+	up := tuple.Vector(0.0, 1.0, 0.0)
+	c.SetTransform(matrix.ViewTransform(from, to, up))
+
+	// When
+	image, err := c.Render(w)
+	require.NoError(t, err)
+
+	// Then
+	assert.True(t, image.Pixel(5, 5).Equal(color.New(0.38066, 0.47583, 0.2855)))
+}
+
+// Changing the number of workers
+func TestSetWorkers(t *testing.T) {
+	tests := []struct {
+		Workers  int
+		Expected int
+	}{
+		{Workers: 4, Expected: 4},
+		{Workers: 1, Expected: 1},
+		{Workers: 0, Expected: 1},
+		{Workers: -3, Expected: 1},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("%d workers", test.Workers), func(t *testing.T) {
+			// Given
+			c := camera.New(10, 10, math.Pi/2.0)
+
+			// When
+			c.SetWorkers(test.Workers)
+
+			// Then
+			assert.Equal(t, test.Expected, c.Workers())
+		})
+	}
+}
+
+// Parallel rendering produces the same image as serial rendering
+func TestRenderParallel(t *testing.T) {
+	// Given
+	w := render.DefaultWorld()
+	c := camera.New(40, 30, math.Pi/2.0)
+	from := tuple.Point(0.0, 1.0, -5.0)
+	to := tuple.Point(0.0, 0.0, 0.0)
+	up := tuple.Vector(0.0, 1.0, 0.0)
+	c.SetTransform(matrix.ViewTransform(from, to, up))
+
+	// When
+	c.SetWorkers(1)
+	serial, err := c.Render(w)
+	require.NoError(t, err)
+
+	c.SetWorkers(8)
+	parallel, err := c.Render(w)
+	require.NoError(t, err)
+
+	c.SetWorkers(100)
+	overcommitted, err := c.Render(w)
+	require.NoError(t, err)
+
+	// Then
+	assert.Equal(t, serial, parallel)
+	assert.Equal(t, serial, overcommitted)
+}
+
+// Constructing a ray through a sample point of the pixel
+func TestRayForSample(t *testing.T) {
+	// Given
+	c := camera.New(201, 101, math.Pi/2.0)
+
+	// When
+	center := c.RayForSample(100, 50, 0.5, 0.5)
+	corner := c.RayForSample(100, 50, 0.0, 0.0)
+
+	// Then
+	assert.True(t, center.Direction().Equal(c.RayForPixel(100, 50).Direction()))
+	assert.True(t, corner.Direction().Equal(tuple.Vector(0.004975, 0.004975, -0.99998)))
+}
+
+// Changing the number of samples per pixel
+func TestSetSamples(t *testing.T) {
+	tests := []struct {
+		Samples  int
+		Expected int
+	}{
+		{Samples: 16, Expected: 16},
+		{Samples: 1, Expected: 1},
+		{Samples: 0, Expected: 1},
+		{Samples: -2, Expected: 1},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("%d samples", test.Samples), func(t *testing.T) {
+			// Given
+			c := camera.New(10, 10, math.Pi/2.0)
+
+			// When
+			c.SetSamples(test.Samples)
+
+			// Then
+			assert.Equal(t, test.Expected, c.Samples())
+		})
+	}
+}
+
+// Rendering with multiple samples per pixel averages the samples
+func TestRenderSamples(t *testing.T) {
+	// Given
+	w := render.DefaultWorld()
+	c := camera.New(11, 11, math.Pi/2.0)
+	from := tuple.Point(0.0, 0.0, -5.0)
+	to := tuple.Point(0.0, 0.0, 0.0)
+	up := tuple.Vector(0.0, 1.0, 0.0)
+	c.SetTransform(matrix.ViewTransform(from, to, up))
+	c.SetSamples(8)
+
+	// When
+	image, err := c.Render(w)
+	require.NoError(t, err)
+
+	// Then
+	// the corner pixel misses the spheres completely
+	assert.True(t, image.Pixel(0, 0).Equal(color.Black()))
+	// the center pixel is slightly blurred, but stays close to the single sample color
+	assert.InDelta(t, 0.38066, image.Pixel(5, 5).Red(), 0.01)
+	assert.InDelta(t, 0.47583, image.Pixel(5, 5).Green(), 0.01)
+	assert.InDelta(t, 0.2855, image.Pixel(5, 5).Blue(), 0.01)
+}
+
+// Reporting the progress of rendering
+func TestRenderProgress(t *testing.T) {
+	// Given
+	w := render.DefaultWorld()
+	c := camera.New(10, 5, math.Pi/2.0)
+	c.SetWorkers(3)
+
+	var reported []int
+	c.SetProgress(func(done, total int) {
+		assert.Equal(t, 5, total)
+		reported = append(reported, done)
+	})
+
+	// When
+	_, err := c.Render(w)
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, []int{1, 2, 3, 4, 5}, reported)
+}
+
+// panickingShape is a sphere that panics when intersected, like a shape of a malformed world.
+type panickingShape struct {
+	*sphere.Sphere
+}
+
+func (s panickingShape) Intersect(_ ray.Ray, _ shape.Intersections) shape.Intersections {
+	panic("broken shape")
+}
+
+// A panic in a worker is returned as an error
+func TestRenderPanic(t *testing.T) {
+	// Given
+	w := render.DefaultWorld()
+	w.AddObject(panickingShape{sphere.New()})
+	c := camera.New(10, 5, math.Pi/2.0)
+	c.SetWorkers(3)
+
+	// When
+	_, err := c.Render(w)
+
+	// Then
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "broken shape")
+}
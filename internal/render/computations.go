@@ -0,0 +1,190 @@
+package render
+
+import (
+	"math"
+
+	mathUtil "github.com/tyz910/ray-tracer-challenge/internal/math"
+	"github.com/tyz910/ray-tracer-challenge/internal/math/tuple"
+	"github.com/tyz910/ray-tracer-challenge/internal/render/ray"
+	"github.com/tyz910/ray-tracer-challenge/internal/render/shape"
+)
+
+// Computations encapsulates precomputed information relating to the intersection.
+type Computations struct {
+	t   float64
+	obj shape.Shape
+
+	point      tuple.Tuple
+	overPoint  tuple.Tuple
+	underPoint tuple.Tuple
+	eyeVec     tuple.Tuple
+	normalVec  tuple.Tuple
+	reflectVec tuple.Tuple
+	direction  tuple.Tuple
+	inside     bool
+
+	// refractive indices of the materials on either side of the intersection
+	n1, n2 float64
+}
+
+// PrepareComputations precomputes the state of the intersection of the ray with the object.
+// The xs argument is the collection of all intersections of the ray, used to find the materials
+// the ray passes through. If it is omitted, the intersection is assumed to be the only one.
+func PrepareComputations(i *shape.Intersection, r ray.Ray, xs ...shape.Intersection) Computations {
+	comps := Computations{
+		t:         i.T(),
+		obj:       i.Object(),
+		direction: r.Direction(),
+	}
+
+	comps.point = r.Position(comps.t)
+	comps.eyeVec = r.Direction().Negate()
+	comps.normalVec = comps.obj.NormalAt(comps.point, i)
+
+	// the normal points away from the eye, so the hit occurs inside the object
+	if comps.normalVec.Dot(comps.eyeVec) < 0.0 {
+		comps.inside = true
+		comps.normalVec = comps.normalVec.Negate()
+	}
+
+	comps.reflectVec = r.Direction().Reflect(comps.normalVec)
+
+	// points slightly above and below the surface, used to avoid self-intersection acne
+	offset := comps.normalVec.Mul(mathUtil.Epsilon)
+	comps.overPoint = comps.point.Add(offset)
+	comps.underPoint = comps.point.Sub(offset)
+
+	if len(xs) == 0 {
+		xs = shape.Intersections{*i}
+	}
+	comps.n1, comps.n2 = refractiveIndices(i, xs)
+
+	return comps
+}
+
+// refractiveIndices returns the refractive indices of the materials being exited and entered at the hit.
+// It walks the intersections, keeping track of the objects the ray is currently inside of.
+// The hit is found in the collection by value.
+func refractiveIndices(hit *shape.Intersection, xs shape.Intersections) (n1, n2 float64) {
+	var containers []shape.Shape
+
+	lastIndex := func() float64 {
+		if len(containers) == 0 {
+			return 1.0
+		}
+
+		return containers[len(containers)-1].Material().RefractiveIndex()
+	}
+
+	for _, i := range xs {
+		if i == *hit {
+			n1 = lastIndex()
+		}
+
+		// the ray either exits the object it is already inside of, or enters a new one
+		found := false
+		for j, obj := range containers {
+			if obj == i.Object() {
+				containers = append(containers[:j], containers[j+1:]...)
+				found = true
+
+				break
+			}
+		}
+
+		if !found {
+			containers = append(containers, i.Object())
+		}
+
+		if i == *hit {
+			n2 = lastIndex()
+
+			break
+		}
+	}
+
+	return
+}
+
+// T returns the t value of the intersection.
+func (comps Computations) T() float64 {
+	return comps.t
+}
+
+// Object returns the object that was intersected.
+func (comps Computations) Object() shape.Shape {
+	return comps.obj
+}
+
+// Point returns the point of the intersection in world space.
+func (comps Computations) Point() tuple.Tuple {
+	return comps.point
+}
+
+// OverPoint returns the point of the intersection slightly adjusted in the direction of the normal.
+func (comps Computations) OverPoint() tuple.Tuple {
+	return comps.overPoint
+}
+
+// UnderPoint returns the point of the intersection slightly adjusted in the opposite direction of the normal.
+func (comps Computations) UnderPoint() tuple.Tuple {
+	return comps.underPoint
+}
+
+// EyeVec returns the vector pointing back toward the eye.
+func (comps Computations) EyeVec() tuple.Tuple {
+	return comps.eyeVec
+}
+
+// NormalVec returns the normal vector at the point of the intersection, pointing toward the eye.
+func (comps Computations) NormalVec() tuple.Tuple {
+	return comps.normalVec
+}
+
+// ReflectVec returns the direction of the ray reflected at the point of the intersection.
+func (comps Computations) ReflectVec() tuple.Tuple {
+	return comps.reflectVec
+}
+
+// Direction returns the direction of the ray that produced the intersection.
+func (comps Computations) Direction() tuple.Tuple {
+	return comps.direction
+}
+
+// N1 returns the refractive index of the material being exited at the intersection.
+func (comps Computations) N1() float64 {
+	return comps.n1
+}
+
+// N2 returns the refractive index of the material being entered at the intersection.
+func (comps Computations) N2() float64 {
+	return comps.n2
+}
+
+// Schlick returns the reflectance at the intersection, i.e. the fraction of the light that is reflected
+// rather than refracted, using the Schlick approximation of the Fresnel effect.
+func (comps Computations) Schlick() float64 {
+	// find the cosine of the angle between the eye and normal vectors
+	cos := comps.eyeVec.Dot(comps.normalVec)
+
+	// total internal reflection can only occur if n1 > n2
+	if comps.n1 > comps.n2 {
+		n := comps.n1 / comps.n2
+		sin2t := n * n * (1.0 - cos*cos)
+		if sin2t > 1.0 {
+			return 1.0
+		}
+
+		// when n1 > n2, use cos(theta_t) instead
+		cos = math.Sqrt(1.0 - sin2t)
+	}
+
+	r0 := math.Pow((comps.n1-comps.n2)/(comps.n1+comps.n2), 2.0)
+
+	return r0 + (1.0-r0)*math.Pow(1.0-cos, 5.0)
+}
+
+// Inside checks whether the intersection occurs inside the object.
+func (comps Computations) Inside() bool {
+	return comps.inside
+}
@@ -0,0 +1,240 @@
+package render_test
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	mathUtil "github.com/tyz910/ray-tracer-challenge/internal/math"
+	"github.com/tyz910/ray-tracer-challenge/internal/math/matrix"
+	"github.com/tyz910/ray-tracer-challenge/internal/math/tuple"
+	"github.com/tyz910/ray-tracer-challenge/internal/render"
+	"github.com/tyz910/ray-tracer-challenge/internal/render/ray"
+	"github.com/tyz910/ray-tracer-challenge/internal/render/shape"
+	"github.com/tyz910/ray-tracer-challenge/internal/render/shape/plane"
+	"github.com/tyz910/ray-tracer-challenge/internal/render/shape/sphere"
+	"github.com/tyz910/ray-tracer-challenge/internal/render/shape/triangle"
+)
+
+// Precomputing the state of an intersection
+func TestPrepareComputations(t *testing.T) {
+	// Given
+	r := ray.New(tuple.Point(0.0, 0.0, -5.0), tuple.Vector(0.0, 0.0, 1.0))
+	s := sphere.New()
+	i := shape.NewIntersection(4.0, s)
+
+	// When
+	comps := render.PrepareComputations(&i, r)
+
+	// Then
+	assert.Equal(t, i.T(), comps.T())
+	assert.Equal(t, i.Object(), comps.Object())
+	assert.True(t, comps.Point().Equal(tuple.Point(0.0, 0.0, -1.0)))
+	assert.True(t, comps.EyeVec().Equal(tuple.Vector(0.0, 0.0, -1.0)))
+	assert.True(t, comps.NormalVec().Equal(tuple.Vector(0.0, 0.0, -1.0)))
+	assert.True(t, comps.Direction().Equal(r.Direction()))
+}
+
+// Precomputing the reflection vector
+func TestPrepareComputationsReflectVec(t *testing.T) {
+	// Given
+	s := plane.New()
+	r := ray.New(tuple.Point(0.0, 1.0, -1.0), tuple.Vector(0.0, -math.Sqrt2/2.0, math.Sqrt2/2.0))
+	i := shape.NewIntersection(math.Sqrt2, s)
+
+	// When
+	comps := render.PrepareComputations(&i, r)
+
+	// Then
+	assert.True(t, comps.ReflectVec().Equal(tuple.Vector(0.0, math.Sqrt2/2.0, math.Sqrt2/2.0)))
+}
+
+// The hit, when an intersection occurs on the outside
+func TestPrepareComputationsOutside(t *testing.T) {
+	// Given
+	r := ray.New(tuple.Point(0.0, 0.0, -5.0), tuple.Vector(0.0, 0.0, 1.0))
+	s := sphere.New()
+	i := shape.NewIntersection(4.0, s)
+
+	// When
+	comps := render.PrepareComputations(&i, r)
+
+	// Then
+	assert.False(t, comps.Inside())
+}
+
+// The hit, when an intersection occurs on the inside
+func TestPrepareComputationsInside(t *testing.T) {
+	// Given
+	r := ray.New(tuple.Point(0.0, 0.0, 0.0), tuple.Vector(0.0, 0.0, 1.0))
+	s := sphere.New()
+	i := shape.NewIntersection(1.0, s)
+
+	// When
+	comps := render.PrepareComputations(&i, r)
+
+	// Then
+	assert.True(t, comps.Point().Equal(tuple.Point(0.0, 0.0, 1.0)))
+	assert.True(t, comps.EyeVec().Equal(tuple.Vector(0.0, 0.0, -1.0)))
+	assert.True(t, comps.Inside())
+	// normal would have been (0, 0, 1), but is inverted!
+	assert.True(t, comps.NormalVec().Equal(tuple.Vector(0.0, 0.0, -1.0)))
+}
+
+// The hit should offset the point
+func TestPrepareComputationsOverPoint(t *testing.T) {
+	// Given
+	r := ray.New(tuple.Point(0.0, 0.0, -5.0), tuple.Vector(0.0, 0.0, 1.0))
+	s := sphere.New()
+	s.SetTransform(matrix.Translation(0.0, 0.0, 1.0))
+	i := shape.NewIntersection(5.0, s)
+
+	// When
+	comps := render.PrepareComputations(&i, r)
+
+	// Then
+	assert.Less(t, comps.OverPoint().Z(), -mathUtil.Epsilon/2.0)
+	assert.Greater(t, comps.Point().Z(), comps.OverPoint().Z())
+}
+
+// The under point is offset below the surface
+func TestPrepareComputationsUnderPoint(t *testing.T) {
+	// Given
+	r := ray.New(tuple.Point(0.0, 0.0, -5.0), tuple.Vector(0.0, 0.0, 1.0))
+	s := glassSphere(matrix.Translation(0.0, 0.0, 1.0), 1.5)
+	i := shape.NewIntersection(5.0, s)
+	xs := shape.Intersections{i}
+
+	// When
+	comps := render.PrepareComputations(&i, r, xs...)
+
+	// Then
+	assert.Greater(t, comps.UnderPoint().Z(), mathUtil.Epsilon/2.0)
+	assert.Less(t, comps.Point().Z(), comps.UnderPoint().Z())
+}
+
+// Preparing the normal on a smooth triangle
+func TestPrepareComputationsSmoothTriangle(t *testing.T) {
+	// Given
+	tri := triangle.NewSmooth(
+		tuple.Point(0.0, 1.0, 0.0),
+		tuple.Point(-1.0, 0.0, 0.0),
+		tuple.Point(1.0, 0.0, 0.0),
+		tuple.Vector(0.0, 1.0, 0.0),
+		tuple.Vector(-1.0, 0.0, 0.0),
+		tuple.Vector(1.0, 0.0, 0.0),
+	)
+	i := shape.NewIntersectionWithUV(1.0, tri, 0.45, 0.25)
+	r := ray.New(tuple.Point(-0.2, 0.3, -2.0), tuple.Vector(0.0, 0.0, 1.0))
+
+	// When
+	comps := render.PrepareComputations(&i, r)
+
+	// Then
+	assert.True(t, comps.NormalVec().Equal(tuple.Vector(-0.5547, 0.83205, 0.0)))
+}
+
+// Finding n1 and n2 at various intersections
+func TestPrepareComputationsRefractiveIndices(t *testing.T) {
+	tests := []struct {
+		Index  int
+		N1, N2 float64
+	}{
+		{Index: 0, N1: 1.0, N2: 1.5},
+		{Index: 1, N1: 1.5, N2: 2.0},
+		{Index: 2, N1: 2.0, N2: 2.5},
+		{Index: 3, N1: 2.5, N2: 2.5},
+		{Index: 4, N1: 2.5, N2: 1.5},
+		{Index: 5, N1: 1.5, N2: 1.0},
+	}
+
+	// Background
+	a := glassSphere(matrix.Scaling(2.0, 2.0, 2.0), 1.5)
+	b := glassSphere(matrix.Translation(0.0, 0.0, -0.25), 2.0)
+	c := glassSphere(matrix.Translation(0.0, 0.0, 0.25), 2.5)
+	r := ray.New(tuple.Point(0.0, 0.0, -4.0), tuple.Vector(0.0, 0.0, 1.0))
+	xs := shape.Intersections{
+		shape.NewIntersection(2.0, a),
+		shape.NewIntersection(2.75, b),
+		shape.NewIntersection(3.25, c),
+		shape.NewIntersection(4.75, b),
+		shape.NewIntersection(5.25, c),
+		shape.NewIntersection(6.0, a),
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("index %d", test.Index), func(t *testing.T) {
+			// When
+			comps := render.PrepareComputations(&xs[test.Index], r, xs...)
+
+			// Then
+			assert.Equal(t, test.N1, comps.N1())
+			assert.Equal(t, test.N2, comps.N2())
+		})
+	}
+}
+
+func TestSchlick(t *testing.T) {
+	tests := []struct {
+		Name        string
+		Ray         ray.Ray
+		T           []float64
+		Hit         int
+		Reflectance float64
+	}{
+		{
+			Name:        "The Schlick approximation under total internal reflection",
+			Ray:         ray.New(tuple.Point(0.0, 0.0, math.Sqrt2/2.0), tuple.Vector(0.0, 1.0, 0.0)),
+			T:           []float64{-math.Sqrt2 / 2.0, math.Sqrt2 / 2.0},
+			Hit:         1,
+			Reflectance: 1.0,
+		},
+
+		{
+			Name:        "The Schlick approximation with a perpendicular viewing angle",
+			Ray:         ray.New(tuple.Point(0.0, 0.0, 0.0), tuple.Vector(0.0, 1.0, 0.0)),
+			T:           []float64{-1.0, 1.0},
+			Hit:         1,
+			Reflectance: 0.04,
+		},
+
+		{
+			Name:        "The Schlick approximation with small angle and n2 > n1",
+			Ray:         ray.New(tuple.Point(0.0, 0.99, -2.0), tuple.Vector(0.0, 0.0, 1.0)),
+			T:           []float64{1.8589},
+			Hit:         0,
+			Reflectance: 0.48873,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			// Given
+			s := sphere.NewGlass()
+			xs := shape.Intersections{}
+			for _, tv := range test.T {
+				xs = append(xs, shape.NewIntersection(tv, s))
+			}
+
+			// When
+			comps := render.PrepareComputations(&xs[test.Hit], test.Ray, xs...)
+			reflectance := comps.Schlick()
+
+			// Then
+			assert.InDelta(t, test.Reflectance, reflectance, mathUtil.Epsilon)
+		})
+	}
+}
+
+func glassSphere(transform matrix.Mat4, refractiveIndex float64) *sphere.Sphere {
+	s := sphere.NewGlass()
+	s.SetTransform(transform)
+
+	m := s.Material()
+	m.SetRefractiveIndex(refractiveIndex)
+	s.SetMaterial(m)
+
+	return s
+}
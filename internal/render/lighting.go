@@ -7,16 +7,24 @@ import (
 	"github.com/tyz910/ray-tracer-challenge/internal/math/tuple"
 	"github.com/tyz910/ray-tracer-challenge/internal/render/light"
 	"github.com/tyz910/ray-tracer-challenge/internal/render/material"
+	"github.com/tyz910/ray-tracer-challenge/internal/render/pattern"
+	"github.com/tyz910/ray-tracer-challenge/internal/render/shape"
 )
 
-// Lighting calculates color for the point on the surface. It expects five arguments:
-// the material of the surface, the point being illuminated, the light source,
-// and the eye and normal vectors from the Phong reflection model.
-func Lighting(m material.Material, l light.Light, point, eyeVec, normalVec tuple.Tuple) color.Color {
+// Lighting calculates color for the point on the surface. It expects seven arguments:
+// the material of the surface, the object being illuminated, the light source, the point being illuminated,
+// the eye and normal vectors from the Phong reflection model, and whether the point is in shadow.
+func Lighting(m material.Material, obj shape.Shape, l light.Light, point, eyeVec, normalVec tuple.Tuple, inShadow bool) color.Color {
 	var ambient, diffuse, specular color.Color
 
+	// use the pattern color instead of the surface color if the material has a pattern
+	surfaceColor := m.Color()
+	if m.Pattern() != nil {
+		surfaceColor = pattern.AtObject(m.Pattern(), obj, point)
+	}
+
 	// combine the surface color with the light's color/intensity
-	effectiveColor := m.Color().Hadamard(l.Intensity())
+	effectiveColor := surfaceColor.Hadamard(l.Intensity())
 
 	// find the direction to the light source
 	lightVec := l.Position().Sub(point).Normalize()
@@ -24,6 +32,11 @@ func Lighting(m material.Material, l light.Light, point, eyeVec, normalVec tuple
 	// compute the ambient contribution
 	ambient = effectiveColor.Mul(m.Ambient())
 
+	// the light is blocked by another object, so only the ambient contribution is left
+	if inShadow {
+		return ambient
+	}
+
 	// lightDotNormal represents the cosine of the angle between the light vector and the normal vector.
 	// A negative number means the light is on the other side of the surface.
 	lightDotNormal := lightVec.Dot(normalVec)
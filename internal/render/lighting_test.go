@@ -11,6 +11,8 @@ import (
 	"github.com/tyz910/ray-tracer-challenge/internal/render"
 	"github.com/tyz910/ray-tracer-challenge/internal/render/light"
 	"github.com/tyz910/ray-tracer-challenge/internal/render/material"
+	"github.com/tyz910/ray-tracer-challenge/internal/render/pattern"
+	"github.com/tyz910/ray-tracer-challenge/internal/render/shape/sphere"
 )
 
 func TestLighting(t *testing.T) {
@@ -19,6 +21,7 @@ func TestLighting(t *testing.T) {
 		EyeVec    tuple.Tuple
 		NormalVec tuple.Tuple
 		Light     light.Light
+		InShadow  bool
 		Color     color.Color
 	}{
 		{
@@ -60,6 +63,15 @@ func TestLighting(t *testing.T) {
 			Light:     light.New(tuple.Point(0.0, 0.0, 10.0), color.White()),
 			Color:     color.New(0.1, 0.1, 0.1),
 		},
+
+		{
+			Name:      "Lighting with the surface in shadow",
+			EyeVec:    tuple.Vector(0.0, 0.0, -1.0),
+			NormalVec: tuple.Vector(0.0, 0.0, -1.0),
+			Light:     light.New(tuple.Point(0.0, 0.0, -10.0), color.White()),
+			InShadow:  true,
+			Color:     color.New(0.1, 0.1, 0.1),
+		},
 	}
 
 	// Background
@@ -74,10 +86,32 @@ func TestLighting(t *testing.T) {
 			normalVec := test.NormalVec
 
 			// When
-			result := render.Lighting(m, l, p, eyeVec, normalVec)
+			result := render.Lighting(m, sphere.New(), l, p, eyeVec, normalVec, test.InShadow)
 
 			// Then
 			assert.True(t, test.Color.Equal(result))
 		})
 	}
 }
+
+// Lighting with a pattern applied
+func TestLightingWithPattern(t *testing.T) {
+	// Given
+	m := material.New()
+	m.SetPattern(pattern.NewStripe(color.White(), color.Black()))
+	m.SetAmbient(1.0)
+	m.SetDiffuse(0.0)
+	m.SetSpecular(0.0)
+	eyeVec := tuple.Vector(0.0, 0.0, -1.0)
+	normalVec := tuple.Vector(0.0, 0.0, -1.0)
+	l := light.New(tuple.Point(0.0, 0.0, -10.0), color.White())
+	s := sphere.New()
+
+	// When
+	c1 := render.Lighting(m, s, l, tuple.Point(0.9, 0.0, 0.0), eyeVec, normalVec, false)
+	c2 := render.Lighting(m, s, l, tuple.Point(1.1, 0.0, 0.0), eyeVec, normalVec, false)
+
+	// Then
+	assert.Equal(t, color.White(), c1)
+	assert.Equal(t, color.Black(), c2)
+}
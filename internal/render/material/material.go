@@ -1,15 +1,23 @@
 package material
 
-import "github.com/tyz910/ray-tracer-challenge/internal/canvas/color"
+import (
+	"github.com/tyz910/ray-tracer-challenge/internal/canvas/color"
+	"github.com/tyz910/ray-tracer-challenge/internal/render/pattern"
+)
 
 // Material encapsulates surface color and four attributes from the Phong reflection model.
 type Material struct {
-	color color.Color
+	color   color.Color
+	pattern pattern.Pattern
 
 	ambient   float64
 	diffuse   float64
 	specular  float64
 	shininess float64
+
+	reflective      float64
+	transparency    float64
+	refractiveIndex float64
 }
 
 // New creates new material.
@@ -21,6 +29,10 @@ func New() Material {
 		diffuse:   0.9,
 		specular:  0.9,
 		shininess: 200.0,
+
+		reflective:      0.0,
+		transparency:    0.0,
+		refractiveIndex: 1.0,
 	}
 }
 
@@ -47,11 +59,32 @@ func (m Material) Shininess() float64 {
 	return m.shininess
 }
 
+// Reflective returns the reflectivity of the material, from 0 for a non-reflective surface to 1 for a perfect mirror.
+func (m Material) Reflective() float64 {
+	return m.reflective
+}
+
+// Transparency returns the transparency of the material, from 0 for an opaque surface to 1 for a fully transparent one.
+func (m Material) Transparency() float64 {
+	return m.transparency
+}
+
+// RefractiveIndex returns the index of refraction, which determines the degree to which light bends
+// when entering or exiting the material. It is 1 for vacuum, 1.33 for water and 1.5 for glass.
+func (m Material) RefractiveIndex() float64 {
+	return m.refractiveIndex
+}
+
 // Color returns the surface color.
 func (m Material) Color() color.Color {
 	return m.color
 }
 
+// Pattern returns the surface pattern, or nil if the material has a flat color.
+func (m Material) Pattern() pattern.Pattern {
+	return m.pattern
+}
+
 // SetAmbient changes the ambient reflection of the material.
 func (m *Material) SetAmbient(ambient float64) {
 	m.ambient = ambient
@@ -72,7 +105,27 @@ func (m *Material) SetShininess(shininess float64) {
 	m.shininess = shininess
 }
 
+// SetReflective changes the reflectivity of the material.
+func (m *Material) SetReflective(reflective float64) {
+	m.reflective = reflective
+}
+
+// SetTransparency changes the transparency of the material.
+func (m *Material) SetTransparency(transparency float64) {
+	m.transparency = transparency
+}
+
+// SetRefractiveIndex changes the index of refraction of the material.
+func (m *Material) SetRefractiveIndex(refractiveIndex float64) {
+	m.refractiveIndex = refractiveIndex
+}
+
 // SetColor changes the surface color of the material.
 func (m *Material) SetColor(c color.Color) {
 	m.color = c
 }
+
+// SetPattern changes the surface pattern of the material. The pattern takes precedence over the surface color.
+func (m *Material) SetPattern(p pattern.Pattern) {
+	m.pattern = p
+}
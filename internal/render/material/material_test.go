@@ -20,4 +20,8 @@ func TestCreateMaterial(t *testing.T) {
 	assert.Equal(t, 0.9, m.Diffuse())
 	assert.Equal(t, 0.9, m.Specular())
 	assert.Equal(t, 200.0, m.Shininess())
+	assert.Nil(t, m.Pattern())
+	assert.Equal(t, 0.0, m.Reflective())
+	assert.Equal(t, 0.0, m.Transparency())
+	assert.Equal(t, 1.0, m.RefractiveIndex())
 }
@@ -0,0 +1,254 @@
+package obj
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/tyz910/ray-tracer-challenge/internal/math/tuple"
+	"github.com/tyz910/ray-tracer-challenge/internal/render/shape"
+	"github.com/tyz910/ray-tracer-challenge/internal/render/shape/group"
+	"github.com/tyz910/ray-tracer-challenge/internal/render/shape/triangle"
+)
+
+// Parser reads Wavefront OBJ files. It keeps the vertices and normals of the file,
+// and the triangles built from its faces, organized in groups.
+type Parser struct {
+	vertices []tuple.Tuple
+	normals  []tuple.Tuple
+
+	defaultGroup *group.Group
+	groups       map[string]*group.Group
+	groupNames   []string
+	current      *group.Group
+
+	ignored int
+}
+
+// ParseFile parses the OBJ file with the given name.
+func ParseFile(filename string) (*Parser, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return Parse(f)
+}
+
+// Parse parses OBJ data from the reader. Unrecognized lines are skipped and counted,
+// while malformed vertex, normal and face records are reported as errors.
+func Parse(r io.Reader) (*Parser, error) {
+	p := &Parser{
+		defaultGroup: group.New(),
+		groups:       make(map[string]*group.Group),
+	}
+	p.current = p.defaultGroup
+
+	scanner := bufio.NewScanner(r)
+	lineNum := 0
+
+	for scanner.Scan() {
+		lineNum++
+
+		if err := p.parseLine(scanner.Text()); err != nil {
+			return nil, fmt.Errorf("line %d: %v", lineNum, err)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return p, nil
+}
+
+// Ignored returns the number of lines that were not recognized by the parser.
+func (p *Parser) Ignored() int {
+	return p.ignored
+}
+
+// Vertex returns the vertex with the given 1-based index.
+func (p *Parser) Vertex(i int) tuple.Tuple {
+	return p.vertices[i-1]
+}
+
+// Vertices returns the number of vertices in the file.
+func (p *Parser) Vertices() int {
+	return len(p.vertices)
+}
+
+// Normal returns the vertex normal with the given 1-based index.
+func (p *Parser) Normal(i int) tuple.Tuple {
+	return p.normals[i-1]
+}
+
+// Normals returns the number of vertex normals in the file.
+func (p *Parser) Normals() int {
+	return len(p.normals)
+}
+
+// DefaultGroup returns the group with the triangles defined before any named group.
+func (p *Parser) DefaultGroup() *group.Group {
+	return p.defaultGroup
+}
+
+// Group returns the named group, or nil if the file has no group with such name.
+func (p *Parser) Group(name string) *group.Group {
+	return p.groups[name]
+}
+
+// ToGroup returns a single group containing all triangles of the file.
+func (p *Parser) ToGroup() *group.Group {
+	g := group.New()
+
+	if len(p.defaultGroup.Children()) > 0 {
+		g.AddChild(p.defaultGroup)
+	}
+
+	for _, name := range p.groupNames {
+		g.AddChild(p.groups[name])
+	}
+
+	return g
+}
+
+func (p *Parser) parseLine(line string) error {
+	fields := strings.Fields(line)
+	if len(fields) == 0 {
+		return nil
+	}
+
+	switch fields[0] {
+	case "v":
+		v, err := parseTuple(fields[1:])
+		if err != nil {
+			return fmt.Errorf("invalid vertex: %v", err)
+		}
+
+		p.vertices = append(p.vertices, v.AsPoint())
+	case "vn":
+		n, err := parseTuple(fields[1:])
+		if err != nil {
+			return fmt.Errorf("invalid vertex normal: %v", err)
+		}
+
+		p.normals = append(p.normals, n.AsVector())
+	case "f":
+		triangles, err := p.parseFace(fields[1:])
+		if err != nil {
+			return fmt.Errorf("invalid face: %v", err)
+		}
+
+		p.current.AddChild(triangles...)
+	case "g":
+		if len(fields) < 2 {
+			return fmt.Errorf("missing group name")
+		}
+
+		p.setGroup(fields[1])
+	default:
+		p.ignored++
+	}
+
+	return nil
+}
+
+func (p *Parser) setGroup(name string) {
+	g, ok := p.groups[name]
+	if !ok {
+		g = group.New()
+		p.groups[name] = g
+		p.groupNames = append(p.groupNames, name)
+	}
+
+	p.current = g
+}
+
+// parseFace converts the face to triangles, using fan triangulation for polygons with more than three vertices.
+func (p *Parser) parseFace(fields []string) ([]shape.Shape, error) {
+	if len(fields) < 3 {
+		return nil, fmt.Errorf("expected at least 3 vertices, got %d", len(fields))
+	}
+
+	vertices := make([]tuple.Tuple, len(fields))
+	normals := make([]tuple.Tuple, len(fields))
+	smooth := true
+
+	for i, field := range fields {
+		// each vertex reference has the form v, v/vt, v//vn or v/vt/vn
+		refs := strings.Split(field, "/")
+
+		vi, err := resolveIndex(refs[0], len(p.vertices))
+		if err != nil {
+			return nil, fmt.Errorf("vertex %q: %v", field, err)
+		}
+
+		vertices[i] = p.vertices[vi]
+
+		if len(refs) < 3 || refs[2] == "" {
+			smooth = false
+			continue
+		}
+
+		ni, err := resolveIndex(refs[2], len(p.normals))
+		if err != nil {
+			return nil, fmt.Errorf("normal %q: %v", field, err)
+		}
+
+		normals[i] = p.normals[ni]
+	}
+
+	triangles := make([]shape.Shape, 0, len(fields)-2)
+	for i := 1; i < len(fields)-1; i++ {
+		if smooth {
+			triangles = append(triangles, triangle.NewSmooth(
+				vertices[0], vertices[i], vertices[i+1],
+				normals[0], normals[i], normals[i+1],
+			))
+		} else {
+			triangles = append(triangles, triangle.New(vertices[0], vertices[i], vertices[i+1]))
+		}
+	}
+
+	return triangles, nil
+}
+
+// resolveIndex converts 1-based (or negative, relative to the end) OBJ index to 0-based index.
+func resolveIndex(s string, count int) (int, error) {
+	i, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, err
+	}
+
+	if i < 0 {
+		i = count + i + 1
+	}
+
+	if i < 1 || i > count {
+		return 0, fmt.Errorf("index out of range")
+	}
+
+	return i - 1, nil
+}
+
+func parseTuple(fields []string) (tuple.Tuple, error) {
+	if len(fields) < 3 {
+		return tuple.Tuple{}, fmt.Errorf("expected 3 coordinates, got %d", len(fields))
+	}
+
+	var xyz [3]float64
+	for i := range xyz {
+		f, err := strconv.ParseFloat(fields[i], 64)
+		if err != nil {
+			return tuple.Tuple{}, err
+		}
+
+		xyz[i] = f
+	}
+
+	return tuple.Vector(xyz[0], xyz[1], xyz[2]), nil
+}
@@ -0,0 +1,225 @@
+package obj_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tyz910/ray-tracer-challenge/internal/math/tuple"
+	"github.com/tyz910/ray-tracer-challenge/internal/render/obj"
+	"github.com/tyz910/ray-tracer-challenge/internal/render/shape/triangle"
+)
+
+// Ignoring unrecognized lines
+func TestIgnored(t *testing.T) {
+	// Given
+	gibberish := `There was a young lady named Bright
+who traveled much faster than light.
+She set out one day
+in a relative way,
+and came back the previous night.`
+
+	// When
+	parser, err := obj.Parse(strings.NewReader(gibberish))
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, 5, parser.Ignored())
+}
+
+// Vertex records
+func TestVertices(t *testing.T) {
+	// Given
+	file := `v -1 1 0
+v -1.0000 0.5000 0.0000
+v 1 0 0
+v 1 1 0`
+
+	// When
+	parser, err := obj.Parse(strings.NewReader(file))
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, 4, parser.Vertices())
+	assert.True(t, parser.Vertex(1).Equal(tuple.Point(-1.0, 1.0, 0.0)))
+	assert.True(t, parser.Vertex(2).Equal(tuple.Point(-1.0, 0.5, 0.0)))
+	assert.True(t, parser.Vertex(3).Equal(tuple.Point(1.0, 0.0, 0.0)))
+	assert.True(t, parser.Vertex(4).Equal(tuple.Point(1.0, 1.0, 0.0)))
+}
+
+// Parsing triangle faces
+func TestFaces(t *testing.T) {
+	// Given
+	file := `v -1 1 0
+v -1 0 0
+v 1 0 0
+v 1 1 0
+
+f 1 2 3
+f 1 3 4`
+
+	// When
+	parser, err := obj.Parse(strings.NewReader(file))
+	require.NoError(t, err)
+	g := parser.DefaultGroup()
+	t1 := g.Children()[0].(*triangle.Triangle)
+	t2 := g.Children()[1].(*triangle.Triangle)
+
+	// Then
+	assert.True(t, t1.P1().Equal(parser.Vertex(1)))
+	assert.True(t, t1.P2().Equal(parser.Vertex(2)))
+	assert.True(t, t1.P3().Equal(parser.Vertex(3)))
+	assert.True(t, t2.P1().Equal(parser.Vertex(1)))
+	assert.True(t, t2.P2().Equal(parser.Vertex(3)))
+	assert.True(t, t2.P3().Equal(parser.Vertex(4)))
+}
+
+// Triangulating polygons
+func TestTriangulation(t *testing.T) {
+	// Given
+	file := `v -1 1 0
+v -1 0 0
+v 1 0 0
+v 1 1 0
+v 0 2 0
+
+f 1 2 3 4 5`
+
+	// When
+	parser, err := obj.Parse(strings.NewReader(file))
+	require.NoError(t, err)
+	g := parser.DefaultGroup()
+	t1 := g.Children()[0].(*triangle.Triangle)
+	t2 := g.Children()[1].(*triangle.Triangle)
+	t3 := g.Children()[2].(*triangle.Triangle)
+
+	// Then
+	assert.True(t, t1.P1().Equal(parser.Vertex(1)))
+	assert.True(t, t1.P2().Equal(parser.Vertex(2)))
+	assert.True(t, t1.P3().Equal(parser.Vertex(3)))
+	assert.True(t, t2.P1().Equal(parser.Vertex(1)))
+	assert.True(t, t2.P2().Equal(parser.Vertex(3)))
+	assert.True(t, t2.P3().Equal(parser.Vertex(4)))
+	assert.True(t, t3.P1().Equal(parser.Vertex(1)))
+	assert.True(t, t3.P2().Equal(parser.Vertex(4)))
+	assert.True(t, t3.P3().Equal(parser.Vertex(5)))
+}
+
+// Triangles in groups
+func TestGroups(t *testing.T) {
+	// Given
+	parser, err := obj.ParseFile("../../../book/files/triangles.obj")
+	require.NoError(t, err)
+
+	// When
+	g1 := parser.Group("FirstGroup")
+	g2 := parser.Group("SecondGroup")
+	t1 := g1.Children()[0].(*triangle.Triangle)
+	t2 := g2.Children()[0].(*triangle.Triangle)
+
+	// Then
+	assert.True(t, t1.P1().Equal(parser.Vertex(1)))
+	assert.True(t, t1.P2().Equal(parser.Vertex(2)))
+	assert.True(t, t1.P3().Equal(parser.Vertex(3)))
+	assert.True(t, t2.P1().Equal(parser.Vertex(1)))
+	assert.True(t, t2.P2().Equal(parser.Vertex(3)))
+	assert.True(t, t2.P3().Equal(parser.Vertex(4)))
+}
+
+// Converting an OBJ file to a group
+func TestToGroup(t *testing.T) {
+	// Given
+	parser, err := obj.ParseFile("../../../book/files/triangles.obj")
+	require.NoError(t, err)
+
+	// When
+	g := parser.ToGroup()
+
+	// Then
+	assert.Contains(t, g.Children(), parser.Group("FirstGroup"))
+	assert.Contains(t, g.Children(), parser.Group("SecondGroup"))
+}
+
+// Vertex normal records
+func TestNormals(t *testing.T) {
+	// Given
+	file := `vn 0 0 1
+vn 0.707 0 -0.707
+vn 1 2 3`
+
+	// When
+	parser, err := obj.Parse(strings.NewReader(file))
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, 3, parser.Normals())
+	assert.True(t, parser.Normal(1).Equal(tuple.Vector(0.0, 0.0, 1.0)))
+	assert.True(t, parser.Normal(2).Equal(tuple.Vector(0.707, 0.0, -0.707)))
+	assert.True(t, parser.Normal(3).Equal(tuple.Vector(1.0, 2.0, 3.0)))
+}
+
+// Faces with normals
+func TestFacesWithNormals(t *testing.T) {
+	// Given
+	file := `v 0 1 0
+v -1 0 0
+v 1 0 0
+
+vn -1 0 0
+vn 1 0 0
+vn 0 1 0
+
+f 1//3 2//1 3//2
+f 1/0/3 2/102/1 3/14/2`
+
+	// When
+	parser, err := obj.Parse(strings.NewReader(file))
+	require.NoError(t, err)
+	g := parser.DefaultGroup()
+	t1 := g.Children()[0].(*triangle.SmoothTriangle)
+	t2 := g.Children()[1].(*triangle.SmoothTriangle)
+
+	// Then
+	assert.True(t, t1.P1().Equal(parser.Vertex(1)))
+	assert.True(t, t1.P2().Equal(parser.Vertex(2)))
+	assert.True(t, t1.P3().Equal(parser.Vertex(3)))
+	assert.True(t, t1.N1().Equal(parser.Normal(3)))
+	assert.True(t, t1.N2().Equal(parser.Normal(1)))
+	assert.True(t, t1.N3().Equal(parser.Normal(2)))
+	assert.True(t, t2.P1().Equal(t1.P1()) && t2.P2().Equal(t1.P2()) && t2.P3().Equal(t1.P3()))
+	assert.True(t, t2.N1().Equal(t1.N1()) && t2.N2().Equal(t1.N2()) && t2.N3().Equal(t1.N3()))
+}
+
+// Malformed records are reported with their line number
+func TestMalformed(t *testing.T) {
+	tests := []struct {
+		Name  string
+		File  string
+		Error string
+	}{
+		{
+			Name:  "invalid vertex",
+			File:  "v 1 0 0\nv 1 x 0",
+			Error: "line 2: invalid vertex",
+		},
+
+		{
+			Name:  "face with unknown vertex",
+			File:  "v 1 0 0\nv 0 1 0\n\nf 1 2 3",
+			Error: "line 4: invalid face",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			// When
+			_, err := obj.Parse(strings.NewReader(test.File))
+
+			// Then
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), test.Error)
+		})
+	}
+}
@@ -0,0 +1,49 @@
+package pattern
+
+import (
+	"github.com/tyz910/ray-tracer-challenge/internal/canvas/color"
+	"github.com/tyz910/ray-tracer-challenge/internal/math/tuple"
+)
+
+// Blend is a pattern that mixes the colors of two patterns at every point.
+type Blend struct {
+	Base
+	a, b   Pattern
+	weight float64
+}
+
+// NewBlend creates new pattern blending the patterns a and b in equal proportions.
+func NewBlend(a, b Pattern) *Blend {
+	p := &Blend{a: a, b: b, weight: 0.5}
+	p.Base = NewBase(p)
+
+	return p
+}
+
+// A returns the first blended pattern.
+func (p *Blend) A() Pattern {
+	return p.a
+}
+
+// B returns the second blended pattern.
+func (p *Blend) B() Pattern {
+	return p.b
+}
+
+// Weight returns the proportion of the second pattern in the blend, from 0 to 1.
+func (p *Blend) Weight() float64 {
+	return p.weight
+}
+
+// SetWeight changes the proportion of the second pattern in the blend.
+func (p *Blend) SetWeight(weight float64) {
+	p.weight = weight
+}
+
+// LocalPatternAt returns the weighted average of the colors of both patterns at the point.
+func (p *Blend) LocalPatternAt(pt tuple.Tuple) color.Color {
+	a := p.a.PatternAt(pt).Mul(1.0 - p.weight)
+	b := p.b.PatternAt(pt).Mul(p.weight)
+
+	return a.Add(b)
+}
@@ -0,0 +1,58 @@
+package pattern_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/tyz910/ray-tracer-challenge/internal/canvas/color"
+	"github.com/tyz910/ray-tracer-challenge/internal/math/matrix"
+	"github.com/tyz910/ray-tracer-challenge/internal/math/tuple"
+	"github.com/tyz910/ray-tracer-challenge/internal/render/pattern"
+)
+
+// Creating a blend pattern
+func TestCreateBlend(t *testing.T) {
+	// Given
+	a := pattern.NewSolid(color.White())
+	b := pattern.NewSolid(color.Black())
+
+	// When
+	p := pattern.NewBlend(a, b)
+
+	// Then
+	assert.Equal(t, a, p.A())
+	assert.Equal(t, b, p.B())
+	assert.Equal(t, 0.5, p.Weight())
+}
+
+func TestBlendAt(t *testing.T) {
+	tests := []struct {
+		Name   string
+		Weight float64
+		Point  tuple.Tuple
+		Color  color.Color
+	}{
+		{Name: "Blending matching stripes", Weight: 0.5, Point: tuple.Point(0.5, 0.0, -0.5), Color: color.White()},
+		{Name: "Blending crossing stripes", Weight: 0.5, Point: tuple.Point(0.5, 0.0, 0.5), Color: color.New(0.5, 0.5, 0.5)},
+		{Name: "Blending with a custom weight", Weight: 0.25, Point: tuple.Point(0.5, 0.0, 0.5), Color: color.New(0.75, 0.75, 0.75)},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			// Given
+			a := pattern.NewStripe(color.White(), color.Black())
+			b := pattern.NewStripe(color.White(), color.Black())
+			b.SetTransform(matrix.RotationY(math.Pi / 2.0))
+			p := pattern.NewBlend(a, b)
+			p.SetWeight(test.Weight)
+
+			// When
+			c := p.PatternAt(test.Point)
+
+			// Then
+			assert.True(t, test.Color.Equal(c))
+		})
+	}
+}
@@ -0,0 +1,47 @@
+package pattern
+
+import (
+	"math"
+
+	"github.com/tyz910/ray-tracer-challenge/internal/canvas/color"
+	"github.com/tyz910/ray-tracer-challenge/internal/math/tuple"
+)
+
+// Checker is a pattern of alternating unit cubes in three dimensions.
+type Checker struct {
+	Base
+	a, b Pattern
+}
+
+// NewChecker creates new checker pattern with the colors a and b.
+func NewChecker(a, b color.Color) *Checker {
+	return NewNestedChecker(NewSolid(a), NewSolid(b))
+}
+
+// NewNestedChecker creates new checker pattern with the patterns a and b filling its color slots.
+func NewNestedChecker(a, b Pattern) *Checker {
+	p := &Checker{a: a, b: b}
+	p.Base = NewBase(p)
+
+	return p
+}
+
+// A returns the pattern of the even cubes.
+func (p *Checker) A() Pattern {
+	return p.a
+}
+
+// B returns the pattern of the odd cubes.
+func (p *Checker) B() Pattern {
+	return p.b
+}
+
+// LocalPatternAt returns the color of the cube containing the point.
+func (p *Checker) LocalPatternAt(pt tuple.Tuple) color.Color {
+	sum := math.Floor(pt.X()) + math.Floor(pt.Y()) + math.Floor(pt.Z())
+	if math.Mod(sum, 2.0) == 0.0 {
+		return p.a.PatternAt(pt)
+	}
+
+	return p.b.PatternAt(pt)
+}
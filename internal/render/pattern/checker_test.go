@@ -0,0 +1,36 @@
+package pattern_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/tyz910/ray-tracer-challenge/internal/canvas/color"
+	"github.com/tyz910/ray-tracer-challenge/internal/math/tuple"
+	"github.com/tyz910/ray-tracer-challenge/internal/render/pattern"
+)
+
+func TestCheckerAt(t *testing.T) {
+	tests := []struct {
+		Name  string
+		Point tuple.Tuple
+		Color color.Color
+	}{
+		{Name: "Checkers should repeat in x", Point: tuple.Point(0.0, 0.0, 0.0), Color: color.White()},
+		{Name: "Checkers should repeat in x", Point: tuple.Point(0.99, 0.0, 0.0), Color: color.White()},
+		{Name: "Checkers should repeat in x", Point: tuple.Point(1.01, 0.0, 0.0), Color: color.Black()},
+		{Name: "Checkers should repeat in y", Point: tuple.Point(0.0, 0.99, 0.0), Color: color.White()},
+		{Name: "Checkers should repeat in y", Point: tuple.Point(0.0, 1.01, 0.0), Color: color.Black()},
+		{Name: "Checkers should repeat in z", Point: tuple.Point(0.0, 0.0, 0.99), Color: color.White()},
+		{Name: "Checkers should repeat in z", Point: tuple.Point(0.0, 0.0, 1.01), Color: color.Black()},
+	}
+
+	// Background
+	p := pattern.NewChecker(color.White(), color.Black())
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			assert.Equal(t, test.Color, p.PatternAt(test.Point))
+		})
+	}
+}
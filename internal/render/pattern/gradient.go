@@ -0,0 +1,48 @@
+package pattern
+
+import (
+	"math"
+
+	"github.com/tyz910/ray-tracer-challenge/internal/canvas/color"
+	"github.com/tyz910/ray-tracer-challenge/internal/math/tuple"
+)
+
+// Gradient is a pattern that linearly blends from one color to another as the x coordinate changes.
+type Gradient struct {
+	Base
+	a, b Pattern
+}
+
+// NewGradient creates new gradient pattern from the color a to the color b.
+func NewGradient(a, b color.Color) *Gradient {
+	return NewNestedGradient(NewSolid(a), NewSolid(b))
+}
+
+// NewNestedGradient creates new gradient pattern from the pattern a to the pattern b.
+func NewNestedGradient(a, b Pattern) *Gradient {
+	p := &Gradient{a: a, b: b}
+	p.Base = NewBase(p)
+
+	return p
+}
+
+// A returns the starting pattern of the gradient.
+func (p *Gradient) A() Pattern {
+	return p.a
+}
+
+// B returns the ending pattern of the gradient.
+func (p *Gradient) B() Pattern {
+	return p.b
+}
+
+// LocalPatternAt returns the color interpolated by the fractional part of the x coordinate.
+func (p *Gradient) LocalPatternAt(pt tuple.Tuple) color.Color {
+	a := p.a.PatternAt(pt)
+	b := p.b.PatternAt(pt)
+
+	distance := b.Sub(a)
+	fraction := pt.X() - math.Floor(pt.X())
+
+	return a.Add(distance.Mul(fraction))
+}
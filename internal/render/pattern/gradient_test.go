@@ -0,0 +1,33 @@
+package pattern_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/tyz910/ray-tracer-challenge/internal/canvas/color"
+	"github.com/tyz910/ray-tracer-challenge/internal/math/tuple"
+	"github.com/tyz910/ray-tracer-challenge/internal/render/pattern"
+)
+
+// A gradient linearly interpolates between colors
+func TestGradientAt(t *testing.T) {
+	tests := []struct {
+		Point tuple.Tuple
+		Color color.Color
+	}{
+		{Point: tuple.Point(0.0, 0.0, 0.0), Color: color.White()},
+		{Point: tuple.Point(0.25, 0.0, 0.0), Color: color.New(0.75, 0.75, 0.75)},
+		{Point: tuple.Point(0.5, 0.0, 0.0), Color: color.New(0.5, 0.5, 0.5)},
+		{Point: tuple.Point(0.75, 0.0, 0.0), Color: color.New(0.25, 0.25, 0.25)},
+	}
+
+	// Background
+	p := pattern.NewGradient(color.White(), color.Black())
+
+	for _, test := range tests {
+		t.Run(test.Point.String(), func(t *testing.T) {
+			assert.True(t, test.Color.Equal(p.PatternAt(test.Point)))
+		})
+	}
+}
@@ -0,0 +1,69 @@
+package pattern
+
+import (
+	"github.com/tyz910/ray-tracer-challenge/internal/canvas/color"
+	"github.com/tyz910/ray-tracer-challenge/internal/math/matrix"
+	"github.com/tyz910/ray-tracer-challenge/internal/math/tuple"
+)
+
+// Pattern is the interface implemented by functions that map points in space to colors.
+type Pattern interface {
+	// PatternAt returns the color of the pattern at the given point in object space.
+	PatternAt(p tuple.Tuple) color.Color
+
+	// Transform returns the transformation matrix assigned to the pattern.
+	Transform() matrix.Mat4
+
+	// SetTransform assigns transformation matrix to the pattern.
+	SetTransform(m matrix.Mat4)
+}
+
+// Local is the interface implemented by patterns that describe their colors in pattern space.
+type Local interface {
+	// LocalPatternAt returns the color of the pattern at the given point, already converted to pattern space.
+	LocalPatternAt(p tuple.Tuple) color.Color
+}
+
+// Object is the interface implemented by shapes the pattern can be applied to.
+type Object interface {
+	// WorldToObject converts the point from world space to object space.
+	WorldToObject(p tuple.Tuple) tuple.Tuple
+}
+
+// AtObject returns the color of the pattern applied to the object at the given point in world space.
+func AtObject(pat Pattern, obj Object, worldPoint tuple.Tuple) color.Color {
+	return pat.PatternAt(obj.WorldToObject(worldPoint))
+}
+
+// Base implements the transformation handling shared by all patterns.
+// The pattern embeds Base and provides only its local colors.
+type Base struct {
+	local     Local
+	transform matrix.Mat4
+	inverse   matrix.Mat4
+}
+
+// NewBase creates new base for the pattern with the identity transformation.
+func NewBase(local Local) Base {
+	return Base{
+		local:     local,
+		transform: matrix.Identity4(),
+		inverse:   matrix.Identity4(),
+	}
+}
+
+// Transform returns the transformation matrix assigned to the pattern.
+func (b *Base) Transform() matrix.Mat4 {
+	return b.transform
+}
+
+// SetTransform assigns transformation matrix to the pattern.
+func (b *Base) SetTransform(m matrix.Mat4) {
+	b.transform = m
+	b.inverse = m.Inverse()
+}
+
+// PatternAt converts the point from object space to pattern space and returns the local color of the pattern.
+func (b *Base) PatternAt(p tuple.Tuple) color.Color {
+	return b.local.LocalPatternAt(b.inverse.TupMul(p))
+}
@@ -0,0 +1,84 @@
+package pattern_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/tyz910/ray-tracer-challenge/internal/canvas/color"
+	"github.com/tyz910/ray-tracer-challenge/internal/math/matrix"
+	"github.com/tyz910/ray-tracer-challenge/internal/math/tuple"
+	"github.com/tyz910/ray-tracer-challenge/internal/render/pattern"
+	"github.com/tyz910/ray-tracer-challenge/internal/render/shape/sphere"
+)
+
+// The default pattern transformation
+func TestDefaultTransform(t *testing.T) {
+	// Given
+	p := pattern.NewTestPattern()
+
+	// Then
+	assert.Equal(t, matrix.Identity4(), p.Transform())
+}
+
+// Assigning a transformation
+func TestSetTransform(t *testing.T) {
+	// Given
+	p := pattern.NewTestPattern()
+
+	// When
+	p.SetTransform(matrix.Translation(1.0, 2.0, 3.0))
+
+	// Then
+	assert.Equal(t, matrix.Translation(1.0, 2.0, 3.0), p.Transform())
+}
+
+func TestAtObject(t *testing.T) {
+	tests := []struct {
+		Name             string
+		ObjectTransform  matrix.Mat4
+		PatternTransform matrix.Mat4
+		Point            tuple.Tuple
+		Color            color.Color
+	}{
+		{
+			Name:             "A pattern with an object transformation",
+			ObjectTransform:  matrix.Scaling(2.0, 2.0, 2.0),
+			PatternTransform: matrix.Identity4(),
+			Point:            tuple.Point(2.0, 3.0, 4.0),
+			Color:            color.New(1.0, 1.5, 2.0),
+		},
+
+		{
+			Name:             "A pattern with a pattern transformation",
+			ObjectTransform:  matrix.Identity4(),
+			PatternTransform: matrix.Scaling(2.0, 2.0, 2.0),
+			Point:            tuple.Point(2.0, 3.0, 4.0),
+			Color:            color.New(1.0, 1.5, 2.0),
+		},
+
+		{
+			Name:             "A pattern with both an object and a pattern transformation",
+			ObjectTransform:  matrix.Scaling(2.0, 2.0, 2.0),
+			PatternTransform: matrix.Translation(0.5, 1.0, 1.5),
+			Point:            tuple.Point(2.5, 3.0, 3.5),
+			Color:            color.New(0.75, 0.5, 0.25),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			// Given
+			s := sphere.New()
+			s.SetTransform(test.ObjectTransform)
+			p := pattern.NewTestPattern()
+			p.SetTransform(test.PatternTransform)
+
+			// When
+			c := pattern.AtObject(p, s, test.Point)
+
+			// Then
+			assert.True(t, test.Color.Equal(c))
+		})
+	}
+}
@@ -0,0 +1,47 @@
+package pattern
+
+import (
+	"github.com/tyz910/ray-tracer-challenge/internal/canvas/color"
+	"github.com/tyz910/ray-tracer-challenge/internal/math/noise"
+	"github.com/tyz910/ray-tracer-challenge/internal/math/tuple"
+)
+
+// Perturbed is a pattern that jitters the points passed to another pattern with Perlin noise,
+// making its edges irregular.
+type Perturbed struct {
+	Base
+	pattern Pattern
+	scale   float64
+}
+
+// NewPerturbed creates new pattern perturbing the pattern by the noise multiplied by scale.
+func NewPerturbed(pattern Pattern, scale float64) *Perturbed {
+	p := &Perturbed{pattern: pattern, scale: scale}
+	p.Base = NewBase(p)
+
+	return p
+}
+
+// Pattern returns the perturbed pattern.
+func (p *Perturbed) Pattern() Pattern {
+	return p.pattern
+}
+
+// Scale returns the maximum offset applied to each coordinate of the point.
+func (p *Perturbed) Scale() float64 {
+	return p.scale
+}
+
+// LocalPatternAt returns the color of the perturbed pattern at the jittered point.
+func (p *Perturbed) LocalPatternAt(pt tuple.Tuple) color.Color {
+	x, y, z := pt.X(), pt.Y(), pt.Z()
+
+	// offset each coordinate by an independent sample of the noise
+	jittered := tuple.Point(
+		x+noise.Perlin(x, y, z)*p.scale,
+		y+noise.Perlin(x, y, z+1.0)*p.scale,
+		z+noise.Perlin(x, y, z+2.0)*p.scale,
+	)
+
+	return p.pattern.PatternAt(jittered)
+}
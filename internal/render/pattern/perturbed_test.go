@@ -0,0 +1,62 @@
+package pattern_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/tyz910/ray-tracer-challenge/internal/canvas/color"
+	"github.com/tyz910/ray-tracer-challenge/internal/math/tuple"
+	"github.com/tyz910/ray-tracer-challenge/internal/render/pattern"
+)
+
+// Creating a perturbed pattern
+func TestCreatePerturbed(t *testing.T) {
+	// Given
+	s := pattern.NewStripe(color.White(), color.Black())
+
+	// When
+	p := pattern.NewPerturbed(s, 0.2)
+
+	// Then
+	assert.Equal(t, s, p.Pattern())
+	assert.Equal(t, 0.2, p.Scale())
+}
+
+// A perturbed pattern is unchanged at the lattice points
+func TestPerturbedAtLattice(t *testing.T) {
+	// Given
+	p := pattern.NewPerturbed(pattern.NewTestPattern(), 0.5)
+
+	// Then
+	assert.Equal(t, color.New(1.0, 2.0, 3.0), p.PatternAt(tuple.Point(1.0, 2.0, 3.0)))
+}
+
+// A perturbed pattern jitters the point by at most the scale
+func TestPerturbedAt(t *testing.T) {
+	// Given
+	p := pattern.NewPerturbed(pattern.NewTestPattern(), 0.5)
+	pt := tuple.Point(1.3, 2.7, -0.4)
+
+	// When
+	c := p.PatternAt(pt)
+
+	// Then
+	assert.False(t, color.New(1.3, 2.7, -0.4).Equal(c))
+	assert.InDelta(t, 1.3, c.Red(), 0.5)
+	assert.InDelta(t, 2.7, c.Green(), 0.5)
+	assert.InDelta(t, -0.4, c.Blue(), 0.5)
+}
+
+// A perturbed scale of zero leaves the pattern unchanged
+func TestPerturbedZeroScale(t *testing.T) {
+	// Given
+	s := pattern.NewStripe(color.White(), color.Black())
+	p := pattern.NewPerturbed(s, 0.0)
+
+	// Then
+	for x := -2.0; x <= 2.0; x += 0.1 {
+		pt := tuple.Point(x, 0.3, 0.7)
+		assert.Equal(t, s.PatternAt(pt), p.PatternAt(pt))
+	}
+}
@@ -0,0 +1,47 @@
+package pattern
+
+import (
+	"math"
+
+	"github.com/tyz910/ray-tracer-challenge/internal/canvas/color"
+	"github.com/tyz910/ray-tracer-challenge/internal/math/tuple"
+)
+
+// Ring is a pattern of concentric rings around the y axis, alternating between two colors.
+type Ring struct {
+	Base
+	a, b Pattern
+}
+
+// NewRing creates new ring pattern with the colors a and b.
+func NewRing(a, b color.Color) *Ring {
+	return NewNestedRing(NewSolid(a), NewSolid(b))
+}
+
+// NewNestedRing creates new ring pattern with the patterns a and b filling its color slots.
+func NewNestedRing(a, b Pattern) *Ring {
+	p := &Ring{a: a, b: b}
+	p.Base = NewBase(p)
+
+	return p
+}
+
+// A returns the pattern of the even rings.
+func (p *Ring) A() Pattern {
+	return p.a
+}
+
+// B returns the pattern of the odd rings.
+func (p *Ring) B() Pattern {
+	return p.b
+}
+
+// LocalPatternAt returns the color of the ring containing the point.
+func (p *Ring) LocalPatternAt(pt tuple.Tuple) color.Color {
+	distance := math.Sqrt(pt.X()*pt.X() + pt.Z()*pt.Z())
+	if math.Mod(math.Floor(distance), 2.0) == 0.0 {
+		return p.a.PatternAt(pt)
+	}
+
+	return p.b.PatternAt(pt)
+}
@@ -0,0 +1,34 @@
+package pattern_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/tyz910/ray-tracer-challenge/internal/canvas/color"
+	"github.com/tyz910/ray-tracer-challenge/internal/math/tuple"
+	"github.com/tyz910/ray-tracer-challenge/internal/render/pattern"
+)
+
+// A ring should extend in both x and z
+func TestRingAt(t *testing.T) {
+	tests := []struct {
+		Point tuple.Tuple
+		Color color.Color
+	}{
+		{Point: tuple.Point(0.0, 0.0, 0.0), Color: color.White()},
+		{Point: tuple.Point(1.0, 0.0, 0.0), Color: color.Black()},
+		{Point: tuple.Point(0.0, 0.0, 1.0), Color: color.Black()},
+		// 0.708 = just slightly more than √2/2
+		{Point: tuple.Point(0.708, 0.0, 0.708), Color: color.Black()},
+	}
+
+	// Background
+	p := pattern.NewRing(color.White(), color.Black())
+
+	for _, test := range tests {
+		t.Run(test.Point.String(), func(t *testing.T) {
+			assert.Equal(t, test.Color, p.PatternAt(test.Point))
+		})
+	}
+}
@@ -0,0 +1,30 @@
+package pattern
+
+import (
+	"github.com/tyz910/ray-tracer-challenge/internal/canvas/color"
+	"github.com/tyz910/ray-tracer-challenge/internal/math/tuple"
+)
+
+// Solid is a pattern of a single flat color. It fills the color slots of other patterns.
+type Solid struct {
+	Base
+	color color.Color
+}
+
+// NewSolid creates new solid pattern of the color c.
+func NewSolid(c color.Color) *Solid {
+	p := &Solid{color: c}
+	p.Base = NewBase(p)
+
+	return p
+}
+
+// Color returns the color of the pattern.
+func (p *Solid) Color() color.Color {
+	return p.color
+}
+
+// LocalPatternAt returns the color of the pattern regardless of the point.
+func (p *Solid) LocalPatternAt(_ tuple.Tuple) color.Color {
+	return p.color
+}
@@ -0,0 +1,38 @@
+package pattern_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/tyz910/ray-tracer-challenge/internal/canvas/color"
+	"github.com/tyz910/ray-tracer-challenge/internal/math/tuple"
+	"github.com/tyz910/ray-tracer-challenge/internal/render/pattern"
+)
+
+// A solid pattern is constant everywhere
+func TestSolidAt(t *testing.T) {
+	// Given
+	p := pattern.NewSolid(color.Red())
+
+	// Then
+	assert.Equal(t, color.Red(), p.Color())
+	assert.Equal(t, color.Red(), p.PatternAt(tuple.Point(0.0, 0.0, 0.0)))
+	assert.Equal(t, color.Red(), p.PatternAt(tuple.Point(-1.5, 2.5, 7.0)))
+}
+
+// A checker of stripes
+func TestNestedPattern(t *testing.T) {
+	// Given
+	a := pattern.NewStripe(color.White(), color.Black())
+	b := pattern.NewStripe(color.Red(), color.Magenta())
+	p := pattern.NewNestedChecker(a, b)
+
+	// Then
+	assert.Equal(t, a, p.A())
+	assert.Equal(t, b, p.B())
+	assert.Equal(t, color.White(), p.PatternAt(tuple.Point(0.5, 0.5, 0.5)))
+	assert.Equal(t, color.Magenta(), p.PatternAt(tuple.Point(1.5, 0.5, 0.5)))
+	assert.Equal(t, color.Red(), p.PatternAt(tuple.Point(0.5, 1.5, 0.5)))
+	assert.Equal(t, color.Black(), p.PatternAt(tuple.Point(1.5, 1.5, 0.5)))
+}
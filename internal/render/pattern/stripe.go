@@ -0,0 +1,46 @@
+package pattern
+
+import (
+	"math"
+
+	"github.com/tyz910/ray-tracer-challenge/internal/canvas/color"
+	"github.com/tyz910/ray-tracer-challenge/internal/math/tuple"
+)
+
+// Stripe is a pattern that alternates between two colors as the x coordinate changes.
+type Stripe struct {
+	Base
+	a, b Pattern
+}
+
+// NewStripe creates new stripe pattern with the colors a and b.
+func NewStripe(a, b color.Color) *Stripe {
+	return NewNestedStripe(NewSolid(a), NewSolid(b))
+}
+
+// NewNestedStripe creates new stripe pattern with the patterns a and b filling its color slots.
+func NewNestedStripe(a, b Pattern) *Stripe {
+	p := &Stripe{a: a, b: b}
+	p.Base = NewBase(p)
+
+	return p
+}
+
+// A returns the pattern of the even stripes.
+func (p *Stripe) A() Pattern {
+	return p.a
+}
+
+// B returns the pattern of the odd stripes.
+func (p *Stripe) B() Pattern {
+	return p.b
+}
+
+// LocalPatternAt returns the color of the stripe containing the point.
+func (p *Stripe) LocalPatternAt(pt tuple.Tuple) color.Color {
+	if math.Mod(math.Floor(pt.X()), 2.0) == 0.0 {
+		return p.a.PatternAt(pt)
+	}
+
+	return p.b.PatternAt(pt)
+}
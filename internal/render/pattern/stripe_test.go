@@ -0,0 +1,97 @@
+package pattern_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/tyz910/ray-tracer-challenge/internal/canvas/color"
+	"github.com/tyz910/ray-tracer-challenge/internal/math/matrix"
+	"github.com/tyz910/ray-tracer-challenge/internal/math/tuple"
+	"github.com/tyz910/ray-tracer-challenge/internal/render/pattern"
+	"github.com/tyz910/ray-tracer-challenge/internal/render/shape/sphere"
+)
+
+// Creating a stripe pattern
+func TestCreateStripe(t *testing.T) {
+	// Given
+	p := pattern.NewStripe(color.White(), color.Black())
+
+	// Then
+	assert.Equal(t, pattern.NewSolid(color.White()), p.A())
+	assert.Equal(t, pattern.NewSolid(color.Black()), p.B())
+}
+
+func TestStripeAt(t *testing.T) {
+	tests := []struct {
+		Name  string
+		Point tuple.Tuple
+		Color color.Color
+	}{
+		{Name: "A stripe pattern is constant in y", Point: tuple.Point(0.0, 1.0, 0.0), Color: color.White()},
+		{Name: "A stripe pattern is constant in y", Point: tuple.Point(0.0, 2.0, 0.0), Color: color.White()},
+		{Name: "A stripe pattern is constant in z", Point: tuple.Point(0.0, 0.0, 1.0), Color: color.White()},
+		{Name: "A stripe pattern is constant in z", Point: tuple.Point(0.0, 0.0, 2.0), Color: color.White()},
+		{Name: "A stripe pattern alternates in x", Point: tuple.Point(0.0, 0.0, 0.0), Color: color.White()},
+		{Name: "A stripe pattern alternates in x", Point: tuple.Point(0.9, 0.0, 0.0), Color: color.White()},
+		{Name: "A stripe pattern alternates in x", Point: tuple.Point(1.0, 0.0, 0.0), Color: color.Black()},
+		{Name: "A stripe pattern alternates in x", Point: tuple.Point(-0.1, 0.0, 0.0), Color: color.Black()},
+		{Name: "A stripe pattern alternates in x", Point: tuple.Point(-1.0, 0.0, 0.0), Color: color.Black()},
+		{Name: "A stripe pattern alternates in x", Point: tuple.Point(-1.1, 0.0, 0.0), Color: color.White()},
+	}
+
+	// Background
+	p := pattern.NewStripe(color.White(), color.Black())
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			assert.Equal(t, test.Color, p.PatternAt(test.Point))
+		})
+	}
+}
+
+func TestStripeAtObject(t *testing.T) {
+	tests := []struct {
+		Name             string
+		ObjectTransform  matrix.Mat4
+		PatternTransform matrix.Mat4
+		Point            tuple.Tuple
+	}{
+		{
+			Name:             "Stripes with an object transformation",
+			ObjectTransform:  matrix.Scaling(2.0, 2.0, 2.0),
+			PatternTransform: matrix.Identity4(),
+			Point:            tuple.Point(1.5, 0.0, 0.0),
+		},
+
+		{
+			Name:             "Stripes with a pattern transformation",
+			ObjectTransform:  matrix.Identity4(),
+			PatternTransform: matrix.Scaling(2.0, 2.0, 2.0),
+			Point:            tuple.Point(1.5, 0.0, 0.0),
+		},
+
+		{
+			Name:             "Stripes with both an object and a pattern transformation",
+			ObjectTransform:  matrix.Scaling(2.0, 2.0, 2.0),
+			PatternTransform: matrix.Translation(0.5, 0.0, 0.0),
+			Point:            tuple.Point(2.5, 0.0, 0.0),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			// Given
+			s := sphere.New()
+			s.SetTransform(test.ObjectTransform)
+			p := pattern.NewStripe(color.White(), color.Black())
+			p.SetTransform(test.PatternTransform)
+
+			// When
+			c := pattern.AtObject(p, s, test.Point)
+
+			// Then
+			assert.Equal(t, color.White(), c)
+		})
+	}
+}
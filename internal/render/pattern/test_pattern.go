@@ -0,0 +1,24 @@
+package pattern
+
+import (
+	"github.com/tyz910/ray-tracer-challenge/internal/canvas/color"
+	"github.com/tyz910/ray-tracer-challenge/internal/math/tuple"
+)
+
+// TestPattern is a pattern used to test the behavior shared by all patterns.
+type TestPattern struct {
+	Base
+}
+
+// NewTestPattern creates new test pattern.
+func NewTestPattern() *TestPattern {
+	p := &TestPattern{}
+	p.Base = NewBase(p)
+
+	return p
+}
+
+// LocalPatternAt returns the color with components equal to the coordinates of the point.
+func (p *TestPattern) LocalPatternAt(pt tuple.Tuple) color.Color {
+	return color.New(pt.X(), pt.Y(), pt.Z())
+}
@@ -45,7 +45,7 @@ func (r Ray) Position(t float64) tuple.Tuple {
 
 // Transform applies the given transformation matrix to the ray,
 // and returns a new ray with transformed origin and direction.
-func (r Ray) Transform(m matrix.Matrix) Ray {
+func (r Ray) Transform(m matrix.Mat4) Ray {
 	return New(
 		m.TupMul(r.origin),
 		m.TupMul(r.direction),
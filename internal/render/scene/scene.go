@@ -0,0 +1,895 @@
+package scene
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/tyz910/ray-tracer-challenge/internal/canvas/color"
+	"github.com/tyz910/ray-tracer-challenge/internal/math/matrix"
+	"github.com/tyz910/ray-tracer-challenge/internal/math/tuple"
+	"github.com/tyz910/ray-tracer-challenge/internal/render"
+	"github.com/tyz910/ray-tracer-challenge/internal/render/camera"
+	"github.com/tyz910/ray-tracer-challenge/internal/render/light"
+	"github.com/tyz910/ray-tracer-challenge/internal/render/material"
+	"github.com/tyz910/ray-tracer-challenge/internal/render/obj"
+	"github.com/tyz910/ray-tracer-challenge/internal/render/pattern"
+	"github.com/tyz910/ray-tracer-challenge/internal/render/shape"
+	"github.com/tyz910/ray-tracer-challenge/internal/render/shape/cone"
+	"github.com/tyz910/ray-tracer-challenge/internal/render/shape/cube"
+	"github.com/tyz910/ray-tracer-challenge/internal/render/shape/cylinder"
+	"github.com/tyz910/ray-tracer-challenge/internal/render/shape/group"
+	"github.com/tyz910/ray-tracer-challenge/internal/render/shape/plane"
+	"github.com/tyz910/ray-tracer-challenge/internal/render/shape/sphere"
+)
+
+// DivideThreshold is the number of children starting from which the groups of the scene are split
+// into a bounding volume hierarchy, i.e. groups with at least DivideThreshold children are split.
+const DivideThreshold = 4
+
+// Error is an error in the scene description at the given line.
+type Error struct {
+	Line int
+	Msg  string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("line %d: %s", e.Line, e.Msg)
+}
+
+// Scene is a world together with the camera looking at it.
+type Scene struct {
+	world    *render.World
+	camera   *camera.Camera
+	warnings []string
+}
+
+// LoadFile loads the scene from the YAML file with the given name.
+// The OBJ files referenced by the scene are looked up relative to the directory of the file.
+func LoadFile(filename string) (*Scene, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return load(f, filepath.Dir(filename))
+}
+
+// Load loads the scene from YAML data in the format of the book scene files. The document is a list of
+// "add" items creating the camera, lights and shapes, and "define" items naming reusable materials,
+// transforms and shapes, which may extend previous definitions.
+func Load(r io.Reader) (*Scene, error) {
+	return load(r, ".")
+}
+
+// World returns the world of the scene.
+func (s *Scene) World() *render.World {
+	return s.world
+}
+
+// Camera returns the camera of the scene.
+func (s *Scene) Camera() *camera.Camera {
+	return s.camera
+}
+
+// Warnings returns the problems that didn't prevent loading the scene, like the lines of OBJ files
+// that were not recognized and ignored.
+func (s *Scene) Warnings() []string {
+	return s.warnings
+}
+
+// loader builds the scene from the parsed document, keeping track of the defined names.
+type loader struct {
+	dir     string
+	scene   *Scene
+	defines map[string]*node
+
+	// names of the defined shapes being expanded, to detect definitions referring to themselves
+	expanding map[string]bool
+}
+
+func load(r io.Reader, dir string) (*Scene, error) {
+	doc, err := parseYAML(r)
+	if err != nil {
+		return nil, err
+	}
+
+	if doc.kind != sequenceNode {
+		return nil, &Error{Line: doc.line, Msg: "expected a list of scene items"}
+	}
+
+	l := &loader{
+		dir:       dir,
+		scene:     &Scene{world: render.NewWorld()},
+		defines:   make(map[string]*node),
+		expanding: make(map[string]bool),
+	}
+
+	for _, item := range doc.items {
+		if err := l.loadItem(item); err != nil {
+			return nil, err
+		}
+	}
+
+	if l.scene.camera == nil {
+		return nil, fmt.Errorf("scene has no camera")
+	}
+
+	return l.scene, nil
+}
+
+func (l *loader) loadItem(n *node) error {
+	if n.kind != mappingNode {
+		return &Error{Line: n.line, Msg: fmt.Sprintf("expected mapping, got %v", n.kind)}
+	}
+
+	if name := n.get("define"); name != nil {
+		return l.define(n, name)
+	}
+
+	add := n.get("add")
+	if add == nil {
+		return &Error{Line: n.line, Msg: `expected "add" or "define" key`}
+	}
+
+	switch add.value {
+	case "camera":
+		return l.addCamera(n)
+	case "light":
+		return l.addLight(n)
+	}
+
+	s, err := l.newShape(n, nil)
+	if err != nil {
+		return err
+	}
+
+	shape.Divide(s, DivideThreshold)
+	l.scene.world.AddObject(s)
+
+	return nil
+}
+
+// define stores the value under the given name. A mapping value may extend a previously defined mapping,
+// in which case its keys override the keys of the extended one.
+func (l *loader) define(n, name *node) error {
+	if err := checkKeys(n, "define", "extend", "value"); err != nil {
+		return err
+	}
+
+	if name.kind != scalarNode || name.value == "" {
+		return &Error{Line: name.line, Msg: "expected definition name"}
+	}
+
+	value := n.get("value")
+	if value == nil {
+		return &Error{Line: n.line, Msg: fmt.Sprintf("definition %q has no value", name.value)}
+	}
+
+	if extend := n.get("extend"); extend != nil {
+		base, err := l.resolve(extend, mappingNode)
+		if err != nil {
+			return err
+		}
+
+		if value.kind != mappingNode {
+			return &Error{Line: value.line, Msg: "only mappings can extend definitions"}
+		}
+
+		value = merge(base, value)
+	}
+
+	l.defines[name.value] = value
+
+	return nil
+}
+
+func (l *loader) addCamera(n *node) error {
+	if err := checkKeys(n, "add", "width", "height", "field-of-view", "from", "to", "up"); err != nil {
+		return err
+	}
+
+	if l.scene.camera != nil {
+		return &Error{Line: n.line, Msg: "duplicate camera"}
+	}
+
+	width, err := intKey(n, "width")
+	if err != nil {
+		return err
+	}
+
+	height, err := intKey(n, "height")
+	if err != nil {
+		return err
+	}
+
+	fov, err := floatKey(n, "field-of-view")
+	if err != nil {
+		return err
+	}
+
+	from, err := tupleKey(n, "from")
+	if err != nil {
+		return err
+	}
+
+	to, err := tupleKey(n, "to")
+	if err != nil {
+		return err
+	}
+
+	up, err := tupleKey(n, "up")
+	if err != nil {
+		return err
+	}
+
+	if width < 1 || height < 1 {
+		return &Error{Line: n.line, Msg: "camera size must be positive"}
+	}
+
+	if from.Equal(to) {
+		return &Error{Line: n.line, Msg: "camera looks from and to the same point"}
+	}
+
+	if up.AsVector().Magnitude() == 0.0 {
+		return &Error{Line: n.line, Msg: "camera up vector is zero"}
+	}
+
+	view := matrix.ViewTransform(from.AsPoint(), to.AsPoint(), up.AsVector())
+	if !view.IsInvertible() {
+		return &Error{Line: n.line, Msg: "camera up vector is parallel to the view direction"}
+	}
+
+	c := camera.New(width, height, fov)
+	c.SetTransform(view)
+	l.scene.camera = c
+
+	return nil
+}
+
+func (l *loader) addLight(n *node) error {
+	if err := checkKeys(n, "add", "at", "intensity"); err != nil {
+		return err
+	}
+
+	at, err := tupleKey(n, "at")
+	if err != nil {
+		return err
+	}
+
+	intensity, err := colorKey(n, "intensity")
+	if err != nil {
+		return err
+	}
+
+	l.scene.world.AddLight(light.New(at.AsPoint(), intensity))
+
+	return nil
+}
+
+// newShape creates the shape described by the node. Shapes without a material of their own
+// get the inherited material of the enclosing group, if any.
+func (l *loader) newShape(n *node, inherited *material.Material) (shape.Shape, error) {
+	kind := n.get("add")
+	if kind == nil {
+		return nil, &Error{Line: n.line, Msg: `expected "add" key`}
+	}
+
+	var s shape.Shape
+	var err error
+
+	switch kind.value {
+	case "sphere":
+		err = checkKeys(n, "add", "material", "transform")
+		s = sphere.New()
+	case "plane":
+		err = checkKeys(n, "add", "material", "transform")
+		s = plane.New()
+	case "cube":
+		err = checkKeys(n, "add", "material", "transform")
+		s = cube.New()
+	case "cylinder":
+		s, err = newCylinder(n)
+	case "cone":
+		s, err = newCone(n)
+	case "group", "obj":
+		// the material is applied to the children when they are created
+	default:
+		return l.newDefinedShape(n, kind, inherited)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	m := inherited
+	if mn := n.get("material"); mn != nil {
+		own, err := l.material(mn)
+		if err != nil {
+			return nil, err
+		}
+
+		m = &own
+	}
+
+	switch kind.value {
+	case "group":
+		s, err = l.newGroup(n, m)
+	case "obj":
+		s, err = l.newOBJ(n, m)
+	default:
+		if m != nil {
+			s.SetMaterial(*m)
+		}
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if tn := n.get("transform"); tn != nil {
+		t, err := l.transform(tn)
+		if err != nil {
+			return nil, err
+		}
+
+		s.SetTransform(t)
+	}
+
+	return s, nil
+}
+
+// newDefinedShape creates the shape from the definition with the given name,
+// overriding the keys of the definition with the keys of the node.
+func (l *loader) newDefinedShape(n, kind *node, inherited *material.Material) (shape.Shape, error) {
+	def, ok := l.defines[kind.value]
+	if !ok || def.kind != mappingNode || def.get("add") == nil {
+		return nil, &Error{Line: kind.line, Msg: fmt.Sprintf("unknown shape %q", kind.value)}
+	}
+
+	if l.expanding[kind.value] {
+		return nil, &Error{Line: kind.line, Msg: fmt.Sprintf("shape %q refers to itself", kind.value)}
+	}
+
+	l.expanding[kind.value] = true
+	defer delete(l.expanding, kind.value)
+
+	expanded := merge(def, n)
+	expanded.set("add", def.get("add"))
+
+	return l.newShape(expanded, inherited)
+}
+
+func newCylinder(n *node) (shape.Shape, error) {
+	if err := checkKeys(n, "add", "material", "transform", "min", "max", "closed"); err != nil {
+		return nil, err
+	}
+
+	c := cylinder.New()
+
+	if v := n.get("min"); v != nil {
+		f, err := parseFloat(v)
+		if err != nil {
+			return nil, err
+		}
+
+		c.SetMinimum(f)
+	}
+
+	if v := n.get("max"); v != nil {
+		f, err := parseFloat(v)
+		if err != nil {
+			return nil, err
+		}
+
+		c.SetMaximum(f)
+	}
+
+	if v := n.get("closed"); v != nil {
+		b, err := parseBool(v)
+		if err != nil {
+			return nil, err
+		}
+
+		c.SetClosed(b)
+	}
+
+	return c, nil
+}
+
+func newCone(n *node) (shape.Shape, error) {
+	if err := checkKeys(n, "add", "material", "transform", "min", "max", "closed"); err != nil {
+		return nil, err
+	}
+
+	c := cone.New()
+
+	if v := n.get("min"); v != nil {
+		f, err := parseFloat(v)
+		if err != nil {
+			return nil, err
+		}
+
+		c.SetMinimum(f)
+	}
+
+	if v := n.get("max"); v != nil {
+		f, err := parseFloat(v)
+		if err != nil {
+			return nil, err
+		}
+
+		c.SetMaximum(f)
+	}
+
+	if v := n.get("closed"); v != nil {
+		b, err := parseBool(v)
+		if err != nil {
+			return nil, err
+		}
+
+		c.SetClosed(b)
+	}
+
+	return c, nil
+}
+
+func (l *loader) newGroup(n *node, m *material.Material) (shape.Shape, error) {
+	if err := checkKeys(n, "add", "material", "transform", "children"); err != nil {
+		return nil, err
+	}
+
+	g := group.New()
+
+	children := n.get("children")
+	if children == nil {
+		return g, nil
+	}
+
+	if children.kind != sequenceNode {
+		return nil, &Error{Line: children.line, Msg: "expected list of children"}
+	}
+
+	for _, cn := range children.items {
+		if cn.kind != mappingNode {
+			return nil, &Error{Line: cn.line, Msg: fmt.Sprintf("expected mapping, got %v", cn.kind)}
+		}
+
+		child, err := l.newShape(cn, m)
+		if err != nil {
+			return nil, err
+		}
+
+		g.AddChild(child)
+	}
+
+	return g, nil
+}
+
+func (l *loader) newOBJ(n *node, m *material.Material) (shape.Shape, error) {
+	if err := checkKeys(n, "add", "material", "transform", "file"); err != nil {
+		return nil, err
+	}
+
+	file := n.get("file")
+	if file == nil || file.kind != scalarNode || file.value == "" {
+		return nil, &Error{Line: n.line, Msg: "expected OBJ file name"}
+	}
+
+	filename := file.value
+	if !filepath.IsAbs(filename) {
+		filename = filepath.Join(l.dir, filename)
+	}
+
+	p, err := obj.ParseFile(filename)
+	if err != nil {
+		return nil, &Error{Line: file.line, Msg: fmt.Sprintf("failed to load %q: %v", file.value, err)}
+	}
+
+	if ignored := p.Ignored(); ignored > 0 {
+		l.scene.warnings = append(l.scene.warnings,
+			fmt.Sprintf("line %d: %d unrecognized lines of %q were ignored", file.line, ignored, file.value))
+	}
+
+	g := p.ToGroup()
+	if m != nil {
+		setMaterial(g, *m)
+	}
+
+	return g, nil
+}
+
+// setMaterial sets the material of all primitive shapes in the group and its subgroups.
+func setMaterial(g *group.Group, m material.Material) {
+	for _, child := range g.Children() {
+		if sub, ok := child.(*group.Group); ok {
+			setMaterial(sub, m)
+		} else {
+			child.SetMaterial(m)
+		}
+	}
+}
+
+func (l *loader) material(n *node) (material.Material, error) {
+	m := material.New()
+
+	n, err := l.resolve(n, mappingNode)
+	if err != nil {
+		return m, err
+	}
+
+	for _, key := range n.keys {
+		v := n.get(key)
+
+		var f float64
+		switch key {
+		case "color":
+			c, err := parseColor(v)
+			if err != nil {
+				return m, err
+			}
+
+			m.SetColor(c)
+			continue
+		case "pattern":
+			p, err := l.pattern(v)
+			if err != nil {
+				return m, err
+			}
+
+			m.SetPattern(p)
+			continue
+		case "ambient", "diffuse", "specular", "shininess", "reflective", "transparency", "refractive-index":
+			if f, err = parseFloat(v); err != nil {
+				return m, err
+			}
+		default:
+			return m, &Error{Line: v.line, Msg: fmt.Sprintf("unknown material key %q", key)}
+		}
+
+		switch key {
+		case "ambient":
+			m.SetAmbient(f)
+		case "diffuse":
+			m.SetDiffuse(f)
+		case "specular":
+			m.SetSpecular(f)
+		case "shininess":
+			m.SetShininess(f)
+		case "reflective":
+			m.SetReflective(f)
+		case "transparency":
+			m.SetTransparency(f)
+		case "refractive-index":
+			m.SetRefractiveIndex(f)
+		}
+	}
+
+	return m, nil
+}
+
+func (l *loader) pattern(n *node) (pattern.Pattern, error) {
+	n, err := l.resolve(n, mappingNode)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := checkKeys(n, "type", "colors", "transform"); err != nil {
+		return nil, err
+	}
+
+	colors := n.get("colors")
+	if colors == nil || colors.kind != sequenceNode || len(colors.items) != 2 {
+		return nil, &Error{Line: n.line, Msg: "pattern expects a list of two colors"}
+	}
+
+	a, err := parseColor(colors.items[0])
+	if err != nil {
+		return nil, err
+	}
+
+	b, err := parseColor(colors.items[1])
+	if err != nil {
+		return nil, err
+	}
+
+	var p pattern.Pattern
+
+	typ := n.get("type")
+	if typ == nil {
+		return nil, &Error{Line: n.line, Msg: "pattern has no type"}
+	}
+
+	switch typ.value {
+	case "stripes":
+		p = pattern.NewStripe(a, b)
+	case "gradient":
+		p = pattern.NewGradient(a, b)
+	case "rings":
+		p = pattern.NewRing(a, b)
+	case "checkers":
+		p = pattern.NewChecker(a, b)
+	default:
+		return nil, &Error{Line: typ.line, Msg: fmt.Sprintf("unknown pattern type %q", typ.value)}
+	}
+
+	if tn := n.get("transform"); tn != nil {
+		t, err := l.transform(tn)
+		if err != nil {
+			return nil, err
+		}
+
+		p.SetTransform(t)
+	}
+
+	return p, nil
+}
+
+// transform converts the list of transformations, applied in the listed order, to a single matrix.
+// Items of the list are either transformations like [ translate, x, y, z ] or names of defined lists.
+// The resulting matrix must be invertible, so that rays can be converted to object space.
+func (l *loader) transform(n *node) (matrix.Mat4, error) {
+	transformations, err := l.transformations(n, nil)
+	if err != nil {
+		return matrix.Mat4{}, err
+	}
+
+	m := matrix.Transform(transformations...)
+	if !m.IsInvertible() {
+		return matrix.Mat4{}, &Error{Line: n.line, Msg: "transformation is not invertible"}
+	}
+
+	return m, nil
+}
+
+func (l *loader) transformations(n *node, acc []matrix.Mat4) ([]matrix.Mat4, error) {
+	name := n
+	n, err := l.resolve(n, sequenceNode)
+	if err != nil {
+		return nil, err
+	}
+
+	if name.kind == scalarNode {
+		if l.expanding[name.value] {
+			return nil, &Error{Line: name.line, Msg: fmt.Sprintf("recursive definition of %s", name.value)}
+		}
+
+		l.expanding[name.value] = true
+		defer delete(l.expanding, name.value)
+	}
+
+	for _, item := range n.items {
+		if item.kind == scalarNode {
+			if acc, err = l.transformations(item, acc); err != nil {
+				return nil, err
+			}
+
+			continue
+		}
+
+		t, err := parseTransformation(item)
+		if err != nil {
+			return nil, err
+		}
+
+		acc = append(acc, t)
+	}
+
+	return acc, nil
+}
+
+func parseTransformation(n *node) (matrix.Mat4, error) {
+	if n.kind != sequenceNode || len(n.items) == 0 || n.items[0].kind != scalarNode {
+		return matrix.Mat4{}, &Error{Line: n.line, Msg: "expected transformation like [ translate, x, y, z ]"}
+	}
+
+	op := n.items[0].value
+	args := make([]float64, len(n.items)-1)
+
+	for i, item := range n.items[1:] {
+		f, err := parseFloat(item)
+		if err != nil {
+			return matrix.Mat4{}, err
+		}
+
+		args[i] = f
+	}
+
+	expected := map[string]int{
+		"translate": 3,
+		"scale":     3,
+		"rotate-x":  1,
+		"rotate-y":  1,
+		"rotate-z":  1,
+		"shear":     6,
+	}
+
+	count, ok := expected[op]
+	if !ok {
+		return matrix.Mat4{}, &Error{Line: n.line, Msg: fmt.Sprintf("unknown transformation %q", op)}
+	}
+
+	if len(args) != count {
+		return matrix.Mat4{}, &Error{Line: n.line, Msg: fmt.Sprintf("%s expects %d arguments, got %d", op, count, len(args))}
+	}
+
+	switch op {
+	case "translate":
+		return matrix.Translation(args[0], args[1], args[2]), nil
+	case "scale":
+		return matrix.Scaling(args[0], args[1], args[2]), nil
+	case "rotate-x":
+		return matrix.RotationX(args[0]), nil
+	case "rotate-y":
+		return matrix.RotationY(args[0]), nil
+	case "rotate-z":
+		return matrix.RotationZ(args[0]), nil
+	default:
+		return matrix.Shearing(args[0], args[1], args[2], args[3], args[4], args[5]), nil
+	}
+}
+
+// resolve returns the defined value if the node is a name of a definition, or the node itself otherwise.
+// The result must be of the given kind.
+func (l *loader) resolve(n *node, kind nodeKind) (*node, error) {
+	if n.kind == scalarNode {
+		def, ok := l.defines[n.value]
+		if !ok {
+			return nil, &Error{Line: n.line, Msg: fmt.Sprintf("undefined name %q", n.value)}
+		}
+
+		if def.kind != kind {
+			return nil, &Error{Line: n.line, Msg: fmt.Sprintf("%q is a %v, expected %v", n.value, def.kind, kind)}
+		}
+
+		return def, nil
+	}
+
+	if n.kind != kind {
+		return nil, &Error{Line: n.line, Msg: fmt.Sprintf("expected %v, got %v", kind, n.kind)}
+	}
+
+	return n, nil
+}
+
+// merge returns a new mapping with the keys of both mappings, preferring the values of the override.
+func merge(base, override *node) *node {
+	m := newMapping(override.line)
+
+	for _, key := range base.keys {
+		m.set(key, base.get(key))
+	}
+
+	for _, key := range override.keys {
+		m.set(key, override.get(key))
+	}
+
+	return m
+}
+
+// checkKeys reports the first key of the mapping that is not allowed.
+func checkKeys(n *node, allowed ...string) error {
+	for _, key := range n.keys {
+		found := false
+		for _, a := range allowed {
+			if key == a {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return &Error{Line: n.get(key).line, Msg: fmt.Sprintf("unknown key %q", key)}
+		}
+	}
+
+	return nil
+}
+
+func requireKey(n *node, key string) (*node, error) {
+	v := n.get(key)
+	if v == nil {
+		return nil, &Error{Line: n.line, Msg: fmt.Sprintf("missing key %q", key)}
+	}
+
+	return v, nil
+}
+
+func intKey(n *node, key string) (int, error) {
+	v, err := requireKey(n, key)
+	if err != nil {
+		return 0, err
+	}
+
+	i, err := strconv.Atoi(v.value)
+	if v.kind != scalarNode || err != nil {
+		return 0, &Error{Line: v.line, Msg: fmt.Sprintf("expected integer %q", key)}
+	}
+
+	return i, nil
+}
+
+func floatKey(n *node, key string) (float64, error) {
+	v, err := requireKey(n, key)
+	if err != nil {
+		return 0.0, err
+	}
+
+	return parseFloat(v)
+}
+
+func tupleKey(n *node, key string) (tuple.Tuple, error) {
+	v, err := requireKey(n, key)
+	if err != nil {
+		return tuple.Tuple{}, err
+	}
+
+	xyz, err := parseTriple(v)
+	if err != nil {
+		return tuple.Tuple{}, err
+	}
+
+	return tuple.Vector(xyz[0], xyz[1], xyz[2]), nil
+}
+
+func colorKey(n *node, key string) (color.Color, error) {
+	v, err := requireKey(n, key)
+	if err != nil {
+		return color.Color{}, err
+	}
+
+	return parseColor(v)
+}
+
+func parseFloat(n *node) (float64, error) {
+	if n.kind != scalarNode {
+		return 0.0, &Error{Line: n.line, Msg: fmt.Sprintf("expected number, got %v", n.kind)}
+	}
+
+	f, err := strconv.ParseFloat(n.value, 64)
+	if err != nil {
+		return 0.0, &Error{Line: n.line, Msg: fmt.Sprintf("expected number, got %q", n.value)}
+	}
+
+	return f, nil
+}
+
+func parseBool(n *node) (bool, error) {
+	b, err := strconv.ParseBool(n.value)
+	if n.kind != scalarNode || err != nil {
+		return false, &Error{Line: n.line, Msg: fmt.Sprintf("expected boolean, got %q", n.value)}
+	}
+
+	return b, nil
+}
+
+func parseTriple(n *node) ([3]float64, error) {
+	var xyz [3]float64
+
+	if n.kind != sequenceNode || len(n.items) != 3 {
+		return xyz, &Error{Line: n.line, Msg: "expected list of 3 numbers"}
+	}
+
+	for i, item := range n.items {
+		f, err := parseFloat(item)
+		if err != nil {
+			return xyz, err
+		}
+
+		xyz[i] = f
+	}
+
+	return xyz, nil
+}
+
+func parseColor(n *node) (color.Color, error) {
+	rgb, err := parseTriple(n)
+	if err != nil {
+		return color.Color{}, err
+	}
+
+	return color.New(rgb[0], rgb[1], rgb[2]), nil
+}
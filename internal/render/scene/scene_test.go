@@ -0,0 +1,428 @@
+package scene_test
+
+import (
+	"io/ioutil"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tyz910/ray-tracer-challenge/internal/canvas/color"
+	"github.com/tyz910/ray-tracer-challenge/internal/math/matrix"
+	"github.com/tyz910/ray-tracer-challenge/internal/math/tuple"
+	"github.com/tyz910/ray-tracer-challenge/internal/render/pattern"
+	"github.com/tyz910/ray-tracer-challenge/internal/render/scene"
+	"github.com/tyz910/ray-tracer-challenge/internal/render/shape/cube"
+	"github.com/tyz910/ray-tracer-challenge/internal/render/shape/cylinder"
+	"github.com/tyz910/ray-tracer-challenge/internal/render/shape/group"
+	"github.com/tyz910/ray-tracer-challenge/internal/render/shape/plane"
+	"github.com/tyz910/ray-tracer-challenge/internal/render/shape/sphere"
+)
+
+const camera = `
+- add: camera
+  width: 100
+  height: 50
+  field-of-view: 0.785
+  from: [ 0, 1.5, -5 ]
+  to: [ 0, 1, 0 ]
+  up: [ 0, 1, 0 ]
+`
+
+// Loading the camera and lights
+func TestCameraAndLights(t *testing.T) {
+	// Given
+	file := camera + `
+- add: light
+  at: [ 50, 100, -50 ]
+  intensity: [ 1, 1, 1 ]
+
+# an optional second light
+- add: light
+  at: [ -400, 50, -10 ]
+  intensity: [ 0.2, 0.2, 0.2 ]
+`
+
+	// When
+	s, err := scene.Load(strings.NewReader(file))
+
+	// Then
+	require.NoError(t, err)
+
+	c := s.Camera()
+	assert.Equal(t, 100, c.HSize())
+	assert.Equal(t, 50, c.VSize())
+	assert.Equal(t, 0.785, c.FieldOfView())
+	assert.True(t, c.Transform().Equal(matrix.ViewTransform(
+		tuple.Point(0.0, 1.5, -5.0),
+		tuple.Point(0.0, 1.0, 0.0),
+		tuple.Vector(0.0, 1.0, 0.0),
+	)))
+
+	lights := s.World().Lights()
+	require.Len(t, lights, 2)
+	assert.True(t, lights[0].Position().Equal(tuple.Point(50.0, 100.0, -50.0)))
+	assert.True(t, lights[0].Intensity().Equal(color.New(1.0, 1.0, 1.0)))
+	assert.True(t, lights[1].Position().Equal(tuple.Point(-400.0, 50.0, -10.0)))
+	assert.True(t, lights[1].Intensity().Equal(color.New(0.2, 0.2, 0.2)))
+}
+
+// Loading shapes with materials and transforms
+func TestShapes(t *testing.T) {
+	// Given
+	file := camera + `
+- add: plane
+  material:
+    color: [ 1, 1, 1 ]
+    ambient: 1
+    diffuse: 0
+    specular: 0
+  transform:
+    - [ rotate-x, 1.5707963267948966 ] # pi/2
+    - [ translate, 0, 0, 500 ]
+
+- add: sphere
+  material:
+    color: [ 0.373, 0.404, 0.550 ]
+    shininess: 50
+    reflective: 0.7
+    transparency: 0.7
+    refractive-index: 1.5
+
+- add: cylinder
+  min: -1
+  max: 2
+  closed: true
+`
+
+	// When
+	s, err := scene.Load(strings.NewReader(file))
+
+	// Then
+	require.NoError(t, err)
+
+	objects := s.World().Objects()
+	require.Len(t, objects, 3)
+
+	require.IsType(t, &plane.Plane{}, objects[0])
+	m := objects[0].Material()
+	assert.True(t, m.Color().Equal(color.White()))
+	assert.Equal(t, 1.0, m.Ambient())
+	assert.Equal(t, 0.0, m.Diffuse())
+	assert.Equal(t, 0.0, m.Specular())
+	assert.True(t, objects[0].Transform().Equal(matrix.Transform(
+		matrix.RotationX(math.Pi/2.0),
+		matrix.Translation(0.0, 0.0, 500.0),
+	)))
+
+	require.IsType(t, &sphere.Sphere{}, objects[1])
+	m = objects[1].Material()
+	assert.True(t, m.Color().Equal(color.New(0.373, 0.404, 0.550)))
+	assert.Equal(t, 50.0, m.Shininess())
+	assert.Equal(t, 0.7, m.Reflective())
+	assert.Equal(t, 0.7, m.Transparency())
+	assert.Equal(t, 1.5, m.RefractiveIndex())
+	assert.True(t, objects[1].Transform().Equal(matrix.Identity4()))
+
+	require.IsType(t, &cylinder.Cylinder{}, objects[2])
+	c := objects[2].(*cylinder.Cylinder)
+	assert.Equal(t, -1.0, c.Minimum())
+	assert.Equal(t, 2.0, c.Maximum())
+	assert.True(t, c.Closed())
+}
+
+// Extending defined materials and reusing defined transforms
+func TestDefine(t *testing.T) {
+	// Given
+	file := camera + `
+- define: white-material
+  value:
+    color: [ 1, 1, 1 ]
+    diffuse: 0.7
+    reflective: 0.1
+
+- define: blue-material
+  extend: white-material
+  value:
+    color: [ 0.537, 0.831, 0.914 ]
+
+- define: standard-transform
+  value:
+    - [ translate, 1, -1, 1 ]
+    - [ scale, 0.5, 0.5, 0.5 ]
+
+- define: large-object
+  value:
+    - standard-transform
+    - [ scale, 3.5, 3.5, 3.5 ]
+
+- add: cube
+  material: blue-material
+  transform:
+    - large-object
+    - [ translate, 8.5, 1.5, -0.5 ]
+`
+
+	// When
+	s, err := scene.Load(strings.NewReader(file))
+
+	// Then
+	require.NoError(t, err)
+
+	objects := s.World().Objects()
+	require.Len(t, objects, 1)
+	require.IsType(t, &cube.Cube{}, objects[0])
+
+	m := objects[0].Material()
+	assert.True(t, m.Color().Equal(color.New(0.537, 0.831, 0.914)))
+	assert.Equal(t, 0.7, m.Diffuse())
+	assert.Equal(t, 0.1, m.Reflective())
+
+	assert.True(t, objects[0].Transform().Equal(matrix.Transform(
+		matrix.Translation(1.0, -1.0, 1.0),
+		matrix.Scaling(0.5, 0.5, 0.5),
+		matrix.Scaling(3.5, 3.5, 3.5),
+		matrix.Translation(8.5, 1.5, -0.5),
+	)))
+}
+
+// Loading groups and defined shapes
+func TestGroups(t *testing.T) {
+	// Given
+	file := camera + `
+- define: leg
+  value:
+    add: cylinder
+    min: 0
+    max: 1
+    transform:
+      - [ scale, 0.1, 1, 0.1 ]
+
+- add: group
+  material:
+    color: [ 1, 0, 0 ]
+  transform:
+    - [ translate, 0, 1, 0 ]
+  children:
+    - add: leg
+      transform:
+        - [ translate, 1, 0, 0 ]
+    - add: sphere
+      material:
+        color: [ 0, 0, 1 ]
+`
+
+	// When
+	s, err := scene.Load(strings.NewReader(file))
+
+	// Then
+	require.NoError(t, err)
+
+	objects := s.World().Objects()
+	require.Len(t, objects, 1)
+	require.IsType(t, &group.Group{}, objects[0])
+
+	g := objects[0].(*group.Group)
+	assert.True(t, g.Transform().Equal(matrix.Translation(0.0, 1.0, 0.0)))
+	require.Len(t, g.Children(), 2)
+
+	require.IsType(t, &cylinder.Cylinder{}, g.Children()[0])
+	leg := g.Children()[0].(*cylinder.Cylinder)
+	assert.Equal(t, 1.0, leg.Maximum())
+	assert.True(t, leg.Transform().Equal(matrix.Translation(1.0, 0.0, 0.0)))
+	assert.True(t, leg.Material().Color().Equal(color.New(1.0, 0.0, 0.0)))
+
+	assert.True(t, g.Children()[1].Material().Color().Equal(color.New(0.0, 0.0, 1.0)))
+}
+
+// Loading patterns
+func TestPattern(t *testing.T) {
+	// Given
+	file := camera + `
+- add: plane
+  material:
+    pattern:
+      type: checkers
+      colors:
+        - [ 0.35, 0.35, 0.35 ]
+        - [ 0.65, 0.65, 0.65 ]
+      transform:
+        - [ scale, 0.25, 0.25, 0.25 ]
+`
+
+	// When
+	s, err := scene.Load(strings.NewReader(file))
+
+	// Then
+	require.NoError(t, err)
+
+	p := s.World().Objects()[0].Material().Pattern()
+	require.IsType(t, &pattern.Checker{}, p)
+	assert.True(t, p.Transform().Equal(matrix.Scaling(0.25, 0.25, 0.25)))
+	assert.True(t, p.PatternAt(tuple.Point(0.0, 0.0, 0.0)).Equal(color.New(0.35, 0.35, 0.35)))
+	assert.True(t, p.PatternAt(tuple.Point(0.25, 0.0, 0.0)).Equal(color.New(0.65, 0.65, 0.65)))
+}
+
+// Loading the book cover scene
+func TestCover(t *testing.T) {
+	// When
+	s, err := scene.LoadFile("../../../book/cover.yml")
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, 100, s.Camera().HSize())
+	assert.Len(t, s.World().Lights(), 2)
+	assert.Len(t, s.World().Objects(), 19)
+}
+
+// Loading the example scenes
+func TestExampleScenes(t *testing.T) {
+	files, err := filepath.Glob("../../../scenes/*.yml")
+	require.NoError(t, err)
+	require.NotEmpty(t, files)
+
+	for _, file := range files {
+		t.Run(filepath.Base(file), func(t *testing.T) {
+			// When
+			s, err := scene.LoadFile(file)
+
+			// Then
+			require.NoError(t, err)
+			assert.NotEmpty(t, s.World().Objects())
+			assert.NotEmpty(t, s.World().Lights())
+		})
+	}
+}
+
+// Loading an OBJ file reports the ignored lines
+func TestOBJ(t *testing.T) {
+	// Given
+	dir, err := ioutil.TempDir("", "scene")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	objFile := "v -1 1 0\nv -1 0 0\nv 1 0 0\nf 1 2 3\nmtllib model.mtl\nusemtl red\n"
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "model.obj"), []byte(objFile), 0644))
+
+	sceneFile := filepath.Join(dir, "scene.yml")
+	require.NoError(t, ioutil.WriteFile(sceneFile, []byte(camera+"- add: obj\n  file: model.obj\n"), 0644))
+
+	// When
+	s, err := scene.LoadFile(sceneFile)
+
+	// Then
+	require.NoError(t, err)
+	require.Len(t, s.World().Objects(), 1)
+	require.IsType(t, &group.Group{}, s.World().Objects()[0])
+	assert.Equal(t, []string{`line 10: 2 unrecognized lines of "model.obj" were ignored`}, s.Warnings())
+}
+
+// Reporting errors with line numbers
+func TestErrors(t *testing.T) {
+	tests := []struct {
+		Name string
+		File string
+		Line int
+	}{
+		{
+			Name: "unknown shape",
+			File: "- add: camera\n  width: 10\n  height: 10\n  field-of-view: 1\n  from: [0, 0, 0]\n  to: [0, 0, 1]\n  up: [0, 1, 0]\n- add: teapot\n",
+			Line: 8,
+		},
+		{
+			Name: "malformed number",
+			File: "# comment\n- add: sphere\n  material:\n    ambient: bright\n",
+			Line: 4,
+		},
+		{
+			Name: "unknown transformation",
+			File: "- add: cube\n  transform:\n    - [ translate, 1, 2, 3 ]\n    - [ twist, 1 ]\n",
+			Line: 4,
+		},
+		{
+			Name: "wrong number of arguments",
+			File: "- add: cube\n  transform:\n    - [ scale, 1, 2 ]\n",
+			Line: 3,
+		},
+		{
+			Name: "undefined name",
+			File: "\n\n- add: cube\n  material: gold\n",
+			Line: 4,
+		},
+		{
+			Name: "unterminated sequence",
+			File: "- add: light\n  at: [ 1, 2, 3\n",
+			Line: 2,
+		},
+		{
+			Name: "bad indentation",
+			File: "- add: cube\n  material:\n    ambient: 1\n   diffuse: 1\n",
+			Line: 4,
+		},
+		{
+			Name: "unknown key",
+			File: "- add: sphere\n  radius: 2\n",
+			Line: 2,
+		},
+		{
+			Name: "camera looking at its own position",
+			File: "- add: light\n  at: [ 1, 2, 3 ]\n  intensity: [ 1, 1, 1 ]\n- add: camera\n  width: 10\n  height: 10\n  field-of-view: 1\n  from: [ 0, 1, 0 ]\n  to: [ 0, 1, 0 ]\n  up: [ 0, 1, 0 ]\n",
+			Line: 4,
+		},
+		{
+			Name: "camera up parallel to view direction",
+			File: "- add: camera\n  width: 10\n  height: 10\n  field-of-view: 1\n  from: [ 0, 0, 0 ]\n  to: [ 0, 1, 0 ]\n  up: [ 0, 1, 0 ]\n",
+			Line: 1,
+		},
+		{
+			Name: "camera with zero up vector",
+			File: "- add: camera\n  width: 10\n  height: 10\n  field-of-view: 1\n  from: [ 0, 0, 0 ]\n  to: [ 0, 0, 1 ]\n  up: [ 0, 0, 0 ]\n",
+			Line: 1,
+		},
+		{
+			Name: "singular shape transformation",
+			File: "- add: cube\n  transform:\n    - [ scale, 0, 1, 1 ]\n",
+			Line: 3,
+		},
+		{
+			Name: "singular pattern transformation",
+			File: "- add: plane\n  material:\n    pattern:\n      type: stripes\n      colors:\n        - [ 1, 1, 1 ]\n        - [ 0, 0, 0 ]\n      transform:\n        - [ scale, 1, 0, 1 ]\n",
+			Line: 9,
+		},
+		{
+			Name: "recursive transformation",
+			File: "- define: t\n  value:\n    - [ scale, 2, 2, 2 ]\n    - t\n- add: cube\n  transform: t\n",
+			Line: 4,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			// When
+			_, err := scene.Load(strings.NewReader(test.File))
+
+			// Then
+			require.Error(t, err)
+			require.IsType(t, &scene.Error{}, err)
+			assert.Equal(t, test.Line, err.(*scene.Error).Line)
+		})
+	}
+}
+
+// A scene without a camera
+func TestMissingCamera(t *testing.T) {
+	// Given
+	file := `
+- add: sphere
+`
+
+	// When
+	_, err := scene.Load(strings.NewReader(file))
+
+	// Then
+	assert.Error(t, err)
+}
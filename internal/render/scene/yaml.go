@@ -0,0 +1,440 @@
+package scene
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// nodeKind is the kind of a YAML node.
+type nodeKind int
+
+const (
+	scalarNode nodeKind = iota
+	sequenceNode
+	mappingNode
+)
+
+func (k nodeKind) String() string {
+	switch k {
+	case scalarNode:
+		return "scalar"
+	case sequenceNode:
+		return "sequence"
+	case mappingNode:
+		return "mapping"
+	}
+
+	return "unknown"
+}
+
+// node is a YAML value together with the line it was defined on.
+// Mapping keys are kept in the order of the document.
+type node struct {
+	kind  nodeKind
+	line  int
+	value string
+
+	items []*node
+
+	keys   []string
+	values map[string]*node
+}
+
+// get returns the value of the mapping key, or nil if the mapping has no such key.
+func (n *node) get(key string) *node {
+	return n.values[key]
+}
+
+// set adds the key to the mapping, replacing the previous value.
+func (n *node) set(key string, value *node) {
+	if _, ok := n.values[key]; !ok {
+		n.keys = append(n.keys, key)
+	}
+
+	n.values[key] = value
+}
+
+func newScalar(line int, value string) *node {
+	return &node{kind: scalarNode, line: line, value: value}
+}
+
+func newSequence(line int) *node {
+	return &node{kind: sequenceNode, line: line}
+}
+
+func newMapping(line int) *node {
+	return &node{kind: mappingNode, line: line, values: make(map[string]*node)}
+}
+
+// yamlLine is a non-empty line of the document with comments stripped.
+type yamlLine struct {
+	num    int
+	indent int
+	text   string
+}
+
+// yamlParser reads the subset of YAML used by scene files: block sequences and mappings,
+// flow sequences and plain or quoted scalars.
+type yamlParser struct {
+	lines []yamlLine
+	pos   int
+}
+
+// parseYAML parses the document from the reader into a tree of nodes.
+func parseYAML(r io.Reader) (*node, error) {
+	p := &yamlParser{}
+
+	scanner := bufio.NewScanner(r)
+	lineNum := 0
+
+	for scanner.Scan() {
+		lineNum++
+
+		line := scanner.Text()
+		content := strings.TrimLeft(line, " ")
+		if strings.HasPrefix(content, "\t") {
+			return nil, &Error{Line: lineNum, Msg: "tabs are not allowed for indentation"}
+		}
+
+		text := strings.TrimSpace(stripComment(content))
+		if text == "" || text == "---" {
+			continue
+		}
+
+		p.lines = append(p.lines, yamlLine{num: lineNum, indent: len(line) - len(content), text: text})
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(p.lines) == 0 {
+		return newSequence(1), nil
+	}
+
+	root, err := p.parseBlock(p.lines[0].indent)
+	if err != nil {
+		return nil, err
+	}
+
+	if p.pos < len(p.lines) {
+		return nil, &Error{Line: p.lines[p.pos].num, Msg: "unexpected indentation"}
+	}
+
+	return root, nil
+}
+
+// parseBlock parses the sequence or mapping starting at the current line with the given indentation.
+func (p *yamlParser) parseBlock(indent int) (*node, error) {
+	l := p.lines[p.pos]
+	if l.indent != indent {
+		return nil, &Error{Line: l.num, Msg: "unexpected indentation"}
+	}
+
+	if isSequenceItem(l.text) {
+		return p.parseSequence(indent)
+	}
+
+	if _, _, ok := splitKey(l.text); ok {
+		return p.parseMapping(indent)
+	}
+
+	p.pos++
+
+	return parseInline(l.num, l.text)
+}
+
+func (p *yamlParser) parseSequence(indent int) (*node, error) {
+	seq := newSequence(p.lines[p.pos].num)
+
+	for p.pos < len(p.lines) {
+		l := p.lines[p.pos]
+		if l.indent < indent {
+			break
+		}
+
+		if l.indent > indent {
+			return nil, &Error{Line: l.num, Msg: "unexpected indentation"}
+		}
+
+		if !isSequenceItem(l.text) {
+			return nil, &Error{Line: l.num, Msg: "expected sequence item"}
+		}
+
+		rest := strings.TrimLeft(l.text[1:], " ")
+		if rest == "" {
+			// the item is a nested block on the following lines
+			p.pos++
+			if p.pos >= len(p.lines) || p.lines[p.pos].indent <= indent {
+				seq.items = append(seq.items, newScalar(l.num, ""))
+				continue
+			}
+
+			item, err := p.parseBlock(p.lines[p.pos].indent)
+			if err != nil {
+				return nil, err
+			}
+
+			seq.items = append(seq.items, item)
+			continue
+		}
+
+		// the content after the dash is parsed as a block indented to its own column,
+		// so a mapping can start on the same line as the dash
+		p.lines[p.pos] = yamlLine{num: l.num, indent: indent + len(l.text) - len(rest), text: rest}
+
+		item, err := p.parseBlock(p.lines[p.pos].indent)
+		if err != nil {
+			return nil, err
+		}
+
+		seq.items = append(seq.items, item)
+	}
+
+	return seq, nil
+}
+
+func (p *yamlParser) parseMapping(indent int) (*node, error) {
+	m := newMapping(p.lines[p.pos].num)
+
+	for p.pos < len(p.lines) {
+		l := p.lines[p.pos]
+		if l.indent < indent {
+			break
+		}
+
+		if l.indent > indent {
+			return nil, &Error{Line: l.num, Msg: "unexpected indentation"}
+		}
+
+		key, value, ok := splitKey(l.text)
+		if !ok {
+			return nil, &Error{Line: l.num, Msg: fmt.Sprintf("expected key, got %q", l.text)}
+		}
+
+		if m.get(key) != nil {
+			return nil, &Error{Line: l.num, Msg: fmt.Sprintf("duplicate key %q", key)}
+		}
+
+		p.pos++
+
+		if value != "" {
+			v, err := parseInline(l.num, value)
+			if err != nil {
+				return nil, err
+			}
+
+			m.set(key, v)
+			continue
+		}
+
+		// a nested block is indented deeper, except for sequences that may stay on the key's level
+		if p.pos < len(p.lines) {
+			next := p.lines[p.pos]
+			if next.indent > indent || (next.indent == indent && isSequenceItem(next.text)) {
+				v, err := p.parseBlock(next.indent)
+				if err != nil {
+					return nil, err
+				}
+
+				m.set(key, v)
+				continue
+			}
+		}
+
+		m.set(key, newScalar(l.num, ""))
+	}
+
+	return m, nil
+}
+
+// parseInline parses a flow sequence or a scalar written on a single line.
+func parseInline(line int, text string) (*node, error) {
+	if strings.HasPrefix(text, "{") {
+		return nil, &Error{Line: line, Msg: "flow mappings are not supported"}
+	}
+
+	if !strings.HasPrefix(text, "[") {
+		s, err := unquote(text)
+		if err != nil {
+			return nil, &Error{Line: line, Msg: err.Error()}
+		}
+
+		return newScalar(line, s), nil
+	}
+
+	f := &flowParser{line: line, text: text}
+
+	n, err := f.parseSequence()
+	if err != nil {
+		return nil, err
+	}
+
+	f.skipSpaces()
+	if f.pos < len(f.text) {
+		return nil, f.errorf("unexpected %q after sequence", f.text[f.pos:])
+	}
+
+	return n, nil
+}
+
+// flowParser parses flow sequences like [ translate, 1, 2, 3 ], which may be nested.
+type flowParser struct {
+	line int
+	text string
+	pos  int
+}
+
+func (f *flowParser) parseSequence() (*node, error) {
+	seq := newSequence(f.line)
+
+	// skip the opening bracket
+	f.pos++
+
+	f.skipSpaces()
+	if f.peek() == ']' {
+		f.pos++
+		return seq, nil
+	}
+
+	for {
+		f.skipSpaces()
+
+		var item *node
+		switch f.peek() {
+		case 0:
+			return nil, f.errorf("unterminated sequence")
+		case '[':
+			var err error
+			if item, err = f.parseSequence(); err != nil {
+				return nil, err
+			}
+		case '{':
+			return nil, f.errorf("flow mappings are not supported")
+		default:
+			var err error
+			if item, err = f.parseScalar(); err != nil {
+				return nil, err
+			}
+		}
+
+		seq.items = append(seq.items, item)
+
+		f.skipSpaces()
+		switch f.peek() {
+		case ',':
+			f.pos++
+		case ']':
+			f.pos++
+			return seq, nil
+		case 0:
+			return nil, f.errorf("unterminated sequence")
+		default:
+			return nil, f.errorf("unexpected %q in sequence", f.peek())
+		}
+	}
+}
+
+func (f *flowParser) parseScalar() (*node, error) {
+	start := f.pos
+
+	if q := f.peek(); q == '"' || q == '\'' {
+		end := strings.IndexByte(f.text[start+1:], q)
+		if end < 0 {
+			return nil, f.errorf("unterminated string")
+		}
+
+		f.pos = start + end + 2
+	} else {
+		for f.pos < len(f.text) && f.text[f.pos] != ',' && f.text[f.pos] != ']' {
+			f.pos++
+		}
+	}
+
+	s, err := unquote(strings.TrimSpace(f.text[start:f.pos]))
+	if err != nil {
+		return nil, f.errorf("%v", err)
+	}
+
+	return newScalar(f.line, s), nil
+}
+
+func (f *flowParser) peek() byte {
+	if f.pos >= len(f.text) {
+		return 0
+	}
+
+	return f.text[f.pos]
+}
+
+func (f *flowParser) skipSpaces() {
+	for f.pos < len(f.text) && f.text[f.pos] == ' ' {
+		f.pos++
+	}
+}
+
+func (f *flowParser) errorf(format string, args ...interface{}) error {
+	return &Error{Line: f.line, Msg: fmt.Sprintf(format, args...)}
+}
+
+func isSequenceItem(text string) bool {
+	return text == "-" || strings.HasPrefix(text, "- ")
+}
+
+// splitKey splits the "key: value" line. The value is empty when it is given by a nested block.
+func splitKey(text string) (key, value string, ok bool) {
+	if strings.HasPrefix(text, "[") || strings.HasPrefix(text, "\"") || strings.HasPrefix(text, "'") {
+		return "", "", false
+	}
+
+	for i := 0; i < len(text); i++ {
+		if text[i] == ':' && (i+1 == len(text) || text[i+1] == ' ') {
+			return strings.TrimSpace(text[:i]), strings.TrimSpace(text[i+1:]), true
+		}
+	}
+
+	return "", "", false
+}
+
+// stripComment removes the comment starting with # at the beginning of the line or after a space.
+func stripComment(text string) string {
+	var quote byte
+	for i := 0; i < len(text); i++ {
+		c := text[i]
+
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '#' && (i == 0 || text[i-1] == ' '):
+			return text[:i]
+		}
+	}
+
+	return text
+}
+
+func unquote(s string) (string, error) {
+	if len(s) == 0 {
+		return s, nil
+	}
+
+	switch s[0] {
+	case '"':
+		return strconv.Unquote(s)
+	case '\'':
+		if len(s) < 2 || s[len(s)-1] != '\'' {
+			return "", fmt.Errorf("unterminated string")
+		}
+
+		return strings.Replace(s[1:len(s)-1], "''", "'", -1), nil
+	}
+
+	return s, nil
+}
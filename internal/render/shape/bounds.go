@@ -0,0 +1,190 @@
+package shape
+
+import (
+	"math"
+
+	mathUtil "github.com/tyz910/ray-tracer-challenge/internal/math"
+	"github.com/tyz910/ray-tracer-challenge/internal/math/matrix"
+	"github.com/tyz910/ray-tracer-challenge/internal/math/tuple"
+	"github.com/tyz910/ray-tracer-challenge/internal/render/ray"
+)
+
+// Bounds is an axis-aligned bounding box described by its minimum and maximum corners.
+type Bounds struct {
+	min, max tuple.Tuple
+}
+
+// NewBounds creates new bounding box with the given minimum and maximum corners.
+func NewBounds(min, max tuple.Tuple) Bounds {
+	return Bounds{
+		min: min,
+		max: max,
+	}
+}
+
+// EmptyBounds creates new bounding box containing nothing. Adding a point to it produces the box of that point.
+func EmptyBounds() Bounds {
+	return NewBounds(
+		tuple.Point(math.Inf(1), math.Inf(1), math.Inf(1)),
+		tuple.Point(math.Inf(-1), math.Inf(-1), math.Inf(-1)),
+	)
+}
+
+// Min returns the minimum corner of the bounding box.
+func (b Bounds) Min() tuple.Tuple {
+	return b.min
+}
+
+// Max returns the maximum corner of the bounding box.
+func (b Bounds) Max() tuple.Tuple {
+	return b.max
+}
+
+// IsEmpty checks whether the bounding box contains nothing.
+func (b Bounds) IsEmpty() bool {
+	return b.min.X() > b.max.X() || b.min.Y() > b.max.Y() || b.min.Z() > b.max.Z()
+}
+
+// IsFinite checks whether the bounding box contains something and has no infinite extents.
+func (b Bounds) IsFinite() bool {
+	if b.IsEmpty() {
+		return false
+	}
+
+	for _, v := range []float64{b.min.X(), b.min.Y(), b.min.Z(), b.max.X(), b.max.Y(), b.max.Z()} {
+		if math.IsInf(v, 0) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// AddPoint returns the bounding box enlarged to contain the point.
+func (b Bounds) AddPoint(p tuple.Tuple) Bounds {
+	return NewBounds(
+		tuple.Point(math.Min(b.min.X(), p.X()), math.Min(b.min.Y(), p.Y()), math.Min(b.min.Z(), p.Z())),
+		tuple.Point(math.Max(b.max.X(), p.X()), math.Max(b.max.Y(), p.Y()), math.Max(b.max.Z(), p.Z())),
+	)
+}
+
+// Merge returns the bounding box containing both boxes.
+func (b Bounds) Merge(b2 Bounds) Bounds {
+	if b2.IsEmpty() {
+		return b
+	}
+
+	return b.AddPoint(b2.min).AddPoint(b2.max)
+}
+
+// ContainsPoint checks whether the point lies inside the bounding box or on its surface.
+func (b Bounds) ContainsPoint(p tuple.Tuple) bool {
+	return b.min.X() <= p.X() && p.X() <= b.max.X() &&
+		b.min.Y() <= p.Y() && p.Y() <= b.max.Y() &&
+		b.min.Z() <= p.Z() && p.Z() <= b.max.Z()
+}
+
+// ContainsBounds checks whether the other bounding box lies entirely inside the bounding box.
+func (b Bounds) ContainsBounds(b2 Bounds) bool {
+	return b.ContainsPoint(b2.min) && b.ContainsPoint(b2.max)
+}
+
+// Transform returns the axis-aligned box containing the bounding box transformed by the matrix.
+// Infinite extents are preserved, so the bounds of infinite shapes such as planes stay valid.
+func (b Bounds) Transform(m matrix.Mat4) Bounds {
+	if b.IsEmpty() {
+		return b
+	}
+
+	min := [3]float64{b.min.X(), b.min.Y(), b.min.Z()}
+	max := [3]float64{b.max.X(), b.max.Y(), b.max.Z()}
+
+	var newMin, newMax [3]float64
+	for row := 0; row < 3; row++ {
+		// start with the translation and accumulate the extreme contribution of every axis
+		newMin[row] = m.Value(row, 3)
+		newMax[row] = m.Value(row, 3)
+
+		for col := 0; col < 3; col++ {
+			v := m.Value(row, col)
+			if v == 0.0 {
+				// skip the axis to avoid 0 * Inf
+				continue
+			}
+
+			a := v * min[col]
+			c := v * max[col]
+			newMin[row] += math.Min(a, c)
+			newMax[row] += math.Max(a, c)
+		}
+	}
+
+	return NewBounds(
+		tuple.Point(newMin[0], newMin[1], newMin[2]),
+		tuple.Point(newMax[0], newMax[1], newMax[2]),
+	)
+}
+
+// Intersects checks whether the ray intersects the bounding box.
+func (b Bounds) Intersects(r ray.Ray) bool {
+	if b.IsEmpty() {
+		return false
+	}
+
+	xtMin, xtMax := checkAxis(r.Origin().X(), r.Direction().X(), b.min.X(), b.max.X())
+	ytMin, ytMax := checkAxis(r.Origin().Y(), r.Direction().Y(), b.min.Y(), b.max.Y())
+	ztMin, ztMax := checkAxis(r.Origin().Z(), r.Direction().Z(), b.min.Z(), b.max.Z())
+
+	tMin := math.Max(xtMin, math.Max(ytMin, ztMin))
+	tMax := math.Min(xtMax, math.Min(ytMax, ztMax))
+
+	return tMin <= tMax
+}
+
+// Split divides the bounding box in half along its longest axis. The bounding box must be finite.
+func (b Bounds) Split() (left, right Bounds) {
+	dx := b.max.X() - b.min.X()
+	dy := b.max.Y() - b.min.Y()
+	dz := b.max.Z() - b.min.Z()
+
+	x0, y0, z0 := b.min.X(), b.min.Y(), b.min.Z()
+	x1, y1, z1 := b.max.X(), b.max.Y(), b.max.Z()
+
+	greatest := math.Max(dx, math.Max(dy, dz))
+	switch greatest {
+	case dx:
+		x0 = x0 + dx/2.0
+		x1 = x0
+	case dy:
+		y0 = y0 + dy/2.0
+		y1 = y0
+	default:
+		z0 = z0 + dz/2.0
+		z1 = z0
+	}
+
+	left = NewBounds(b.min, tuple.Point(x1, y1, z1))
+	right = NewBounds(tuple.Point(x0, y0, z0), b.max)
+
+	return
+}
+
+// checkAxis returns the t values where the ray intersects the pair of planes at min and max along a single axis.
+func checkAxis(origin, direction, min, max float64) (tMin, tMax float64) {
+	tMinNumerator := min - origin
+	tMaxNumerator := max - origin
+
+	if math.Abs(direction) >= mathUtil.Epsilon {
+		tMin = tMinNumerator / direction
+		tMax = tMaxNumerator / direction
+	} else {
+		tMin = tMinNumerator * math.Inf(1)
+		tMax = tMaxNumerator * math.Inf(1)
+	}
+
+	if tMin > tMax {
+		tMin, tMax = tMax, tMin
+	}
+
+	return
+}
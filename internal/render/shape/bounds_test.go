@@ -0,0 +1,293 @@
+package shape_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/tyz910/ray-tracer-challenge/internal/math/matrix"
+	"github.com/tyz910/ray-tracer-challenge/internal/math/tuple"
+	"github.com/tyz910/ray-tracer-challenge/internal/render/ray"
+	"github.com/tyz910/ray-tracer-challenge/internal/render/shape"
+)
+
+// Creating an empty bounding box
+func TestEmptyBounds(t *testing.T) {
+	// Given
+	b := shape.EmptyBounds()
+
+	// Then
+	assert.Equal(t, tuple.Point(math.Inf(1), math.Inf(1), math.Inf(1)), b.Min())
+	assert.Equal(t, tuple.Point(math.Inf(-1), math.Inf(-1), math.Inf(-1)), b.Max())
+	assert.True(t, b.IsEmpty())
+}
+
+// Creating a bounding box with volume
+func TestCreateBounds(t *testing.T) {
+	// Given
+	b := shape.NewBounds(tuple.Point(-1.0, -2.0, -3.0), tuple.Point(3.0, 2.0, 1.0))
+
+	// Then
+	assert.Equal(t, tuple.Point(-1.0, -2.0, -3.0), b.Min())
+	assert.Equal(t, tuple.Point(3.0, 2.0, 1.0), b.Max())
+	assert.False(t, b.IsEmpty())
+}
+
+// Checking whether a bounding box is finite
+func TestBoundsIsFinite(t *testing.T) {
+	tests := []struct {
+		Name     string
+		Bounds   shape.Bounds
+		Expected bool
+	}{
+		{Name: "finite", Bounds: shape.NewBounds(tuple.Point(-1.0, -2.0, -3.0), tuple.Point(3.0, 2.0, 1.0)), Expected: true},
+		{Name: "empty", Bounds: shape.EmptyBounds(), Expected: false},
+		{Name: "infinite", Bounds: shape.NewBounds(tuple.Point(math.Inf(-1), 0.0, math.Inf(-1)), tuple.Point(math.Inf(1), 0.0, math.Inf(1))), Expected: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			assert.Equal(t, test.Expected, test.Bounds.IsFinite())
+		})
+	}
+}
+
+// Adding points to an empty bounding box
+func TestBoundsAddPoint(t *testing.T) {
+	// Given
+	b := shape.EmptyBounds()
+
+	// When
+	b = b.AddPoint(tuple.Point(-5.0, 2.0, 0.0))
+	b = b.AddPoint(tuple.Point(7.0, 0.0, -3.0))
+
+	// Then
+	assert.Equal(t, tuple.Point(-5.0, 0.0, -3.0), b.Min())
+	assert.Equal(t, tuple.Point(7.0, 2.0, 0.0), b.Max())
+}
+
+// Adding one bounding box to another
+func TestBoundsMerge(t *testing.T) {
+	// Given
+	b1 := shape.NewBounds(tuple.Point(-5.0, -2.0, 0.0), tuple.Point(7.0, 4.0, 4.0))
+	b2 := shape.NewBounds(tuple.Point(8.0, -7.0, -2.0), tuple.Point(14.0, 2.0, 8.0))
+
+	// When
+	b := b1.Merge(b2)
+
+	// Then
+	assert.Equal(t, tuple.Point(-5.0, -7.0, -2.0), b.Min())
+	assert.Equal(t, tuple.Point(14.0, 4.0, 8.0), b.Max())
+	assert.Equal(t, b1, b1.Merge(shape.EmptyBounds()))
+	assert.Equal(t, b1, shape.EmptyBounds().Merge(b1))
+}
+
+// Checking to see if a box contains a given point
+func TestBoundsContainsPoint(t *testing.T) {
+	tests := []struct {
+		Point    tuple.Tuple
+		Expected bool
+	}{
+		{Point: tuple.Point(5.0, -2.0, 0.0), Expected: true},
+		{Point: tuple.Point(11.0, 4.0, 7.0), Expected: true},
+		{Point: tuple.Point(8.0, 1.0, 3.0), Expected: true},
+		{Point: tuple.Point(3.0, 0.0, 3.0), Expected: false},
+		{Point: tuple.Point(8.0, -4.0, 3.0), Expected: false},
+		{Point: tuple.Point(8.0, 1.0, -1.0), Expected: false},
+		{Point: tuple.Point(13.0, 1.0, 3.0), Expected: false},
+		{Point: tuple.Point(8.0, 5.0, 3.0), Expected: false},
+		{Point: tuple.Point(8.0, 1.0, 8.0), Expected: false},
+	}
+
+	// Background
+	b := shape.NewBounds(tuple.Point(5.0, -2.0, 0.0), tuple.Point(11.0, 4.0, 7.0))
+
+	for _, test := range tests {
+		t.Run(test.Point.String(), func(t *testing.T) {
+			assert.Equal(t, test.Expected, b.ContainsPoint(test.Point))
+		})
+	}
+}
+
+// Checking to see if a box contains a given box
+func TestBoundsContainsBounds(t *testing.T) {
+	tests := []struct {
+		Min, Max tuple.Tuple
+		Expected bool
+	}{
+		{Min: tuple.Point(5.0, -2.0, 0.0), Max: tuple.Point(11.0, 4.0, 7.0), Expected: true},
+		{Min: tuple.Point(6.0, -1.0, 1.0), Max: tuple.Point(10.0, 3.0, 6.0), Expected: true},
+		{Min: tuple.Point(4.0, -3.0, -1.0), Max: tuple.Point(10.0, 3.0, 6.0), Expected: false},
+		{Min: tuple.Point(6.0, -1.0, 1.0), Max: tuple.Point(12.0, 5.0, 8.0), Expected: false},
+	}
+
+	// Background
+	b := shape.NewBounds(tuple.Point(5.0, -2.0, 0.0), tuple.Point(11.0, 4.0, 7.0))
+
+	for _, test := range tests {
+		t.Run(test.Min.String()+" "+test.Max.String(), func(t *testing.T) {
+			assert.Equal(t, test.Expected, b.ContainsBounds(shape.NewBounds(test.Min, test.Max)))
+		})
+	}
+}
+
+// Transforming a bounding box
+func TestBoundsTransform(t *testing.T) {
+	// Given
+	b := shape.NewBounds(tuple.Point(-1.0, -1.0, -1.0), tuple.Point(1.0, 1.0, 1.0))
+	m := matrix.RotationX(math.Pi / 4.0).MatMul(matrix.RotationY(math.Pi / 4.0))
+
+	// When
+	b2 := b.Transform(m)
+
+	// Then
+	assert.True(t, b2.Min().Equal(tuple.Point(-1.41421, -1.70710, -1.70710)))
+	assert.True(t, b2.Max().Equal(tuple.Point(1.41421, 1.70710, 1.70710)))
+}
+
+// Transforming an infinite bounding box
+func TestBoundsTransformInfinite(t *testing.T) {
+	// Given
+	b := shape.NewBounds(tuple.Point(math.Inf(-1), 0.0, math.Inf(-1)), tuple.Point(math.Inf(1), 0.0, math.Inf(1)))
+
+	// When
+	b2 := b.Transform(matrix.Translation(1.0, 2.0, 3.0))
+
+	// Then
+	assert.Equal(t, tuple.Point(math.Inf(-1), 2.0, math.Inf(-1)), b2.Min())
+	assert.Equal(t, tuple.Point(math.Inf(1), 2.0, math.Inf(1)), b2.Max())
+}
+
+// Querying a shape's bounding box in its parent's space
+func TestParentSpaceBounds(t *testing.T) {
+	// Given
+	s := shape.NewTestShape()
+	s.SetTransform(matrix.Translation(1.0, -3.0, 5.0).MatMul(matrix.Scaling(0.5, 2.0, 4.0)))
+
+	// When
+	b := s.ParentSpaceBounds()
+
+	// Then
+	assert.True(t, b.Min().Equal(tuple.Point(0.5, -5.0, 1.0)))
+	assert.True(t, b.Max().Equal(tuple.Point(1.5, -1.0, 9.0)))
+}
+
+func TestBoundsIntersects(t *testing.T) {
+	tests := []struct {
+		Name      string
+		Min, Max  tuple.Tuple
+		Origin    tuple.Tuple
+		Direction tuple.Tuple
+		Expected  bool
+	}{
+		{Name: "Intersecting a ray with a cubic bounding box", Origin: tuple.Point(5.0, 0.5, 0.0), Direction: tuple.Vector(-1.0, 0.0, 0.0), Expected: true},
+		{Name: "Intersecting a ray with a cubic bounding box", Origin: tuple.Point(-5.0, 0.5, 0.0), Direction: tuple.Vector(1.0, 0.0, 0.0), Expected: true},
+		{Name: "Intersecting a ray with a cubic bounding box", Origin: tuple.Point(0.5, 5.0, 0.0), Direction: tuple.Vector(0.0, -1.0, 0.0), Expected: true},
+		{Name: "Intersecting a ray with a cubic bounding box", Origin: tuple.Point(0.5, -5.0, 0.0), Direction: tuple.Vector(0.0, 1.0, 0.0), Expected: true},
+		{Name: "Intersecting a ray with a cubic bounding box", Origin: tuple.Point(0.5, 0.0, 5.0), Direction: tuple.Vector(0.0, 0.0, -1.0), Expected: true},
+		{Name: "Intersecting a ray with a cubic bounding box", Origin: tuple.Point(0.5, 0.0, -5.0), Direction: tuple.Vector(0.0, 0.0, 1.0), Expected: true},
+		{Name: "Intersecting a ray with a cubic bounding box", Origin: tuple.Point(0.0, 0.5, 0.0), Direction: tuple.Vector(0.0, 0.0, 1.0), Expected: true},
+		{Name: "Intersecting a ray with a cubic bounding box", Origin: tuple.Point(-2.0, 0.0, 0.0), Direction: tuple.Vector(2.0, 4.0, 6.0), Expected: false},
+		{Name: "Intersecting a ray with a cubic bounding box", Origin: tuple.Point(0.0, -2.0, 0.0), Direction: tuple.Vector(6.0, 2.0, 4.0), Expected: false},
+		{Name: "Intersecting a ray with a cubic bounding box", Origin: tuple.Point(0.0, 0.0, -2.0), Direction: tuple.Vector(4.0, 6.0, 2.0), Expected: false},
+		{Name: "Intersecting a ray with a cubic bounding box", Origin: tuple.Point(2.0, 0.0, 2.0), Direction: tuple.Vector(0.0, 0.0, -1.0), Expected: false},
+		{Name: "Intersecting a ray with a cubic bounding box", Origin: tuple.Point(0.0, 2.0, 2.0), Direction: tuple.Vector(0.0, -1.0, 0.0), Expected: false},
+		{Name: "Intersecting a ray with a cubic bounding box", Origin: tuple.Point(2.0, 2.0, 0.0), Direction: tuple.Vector(-1.0, 0.0, 0.0), Expected: false},
+
+		{Name: "Intersecting a ray with a non-cubic bounding box", Min: tuple.Point(5.0, -2.0, 0.0), Max: tuple.Point(11.0, 4.0, 7.0), Origin: tuple.Point(15.0, 1.0, 2.0), Direction: tuple.Vector(-1.0, 0.0, 0.0), Expected: true},
+		{Name: "Intersecting a ray with a non-cubic bounding box", Min: tuple.Point(5.0, -2.0, 0.0), Max: tuple.Point(11.0, 4.0, 7.0), Origin: tuple.Point(-5.0, -1.0, 4.0), Direction: tuple.Vector(1.0, 0.0, 0.0), Expected: true},
+		{Name: "Intersecting a ray with a non-cubic bounding box", Min: tuple.Point(5.0, -2.0, 0.0), Max: tuple.Point(11.0, 4.0, 7.0), Origin: tuple.Point(7.0, 6.0, 5.0), Direction: tuple.Vector(0.0, -1.0, 0.0), Expected: true},
+		{Name: "Intersecting a ray with a non-cubic bounding box", Min: tuple.Point(5.0, -2.0, 0.0), Max: tuple.Point(11.0, 4.0, 7.0), Origin: tuple.Point(9.0, -5.0, 6.0), Direction: tuple.Vector(0.0, 1.0, 0.0), Expected: true},
+		{Name: "Intersecting a ray with a non-cubic bounding box", Min: tuple.Point(5.0, -2.0, 0.0), Max: tuple.Point(11.0, 4.0, 7.0), Origin: tuple.Point(8.0, 2.0, 12.0), Direction: tuple.Vector(0.0, 0.0, -1.0), Expected: true},
+		{Name: "Intersecting a ray with a non-cubic bounding box", Min: tuple.Point(5.0, -2.0, 0.0), Max: tuple.Point(11.0, 4.0, 7.0), Origin: tuple.Point(6.0, 0.0, -5.0), Direction: tuple.Vector(0.0, 0.0, 1.0), Expected: true},
+		{Name: "Intersecting a ray with a non-cubic bounding box", Min: tuple.Point(5.0, -2.0, 0.0), Max: tuple.Point(11.0, 4.0, 7.0), Origin: tuple.Point(8.0, 1.0, 3.5), Direction: tuple.Vector(0.0, 0.0, 1.0), Expected: true},
+		{Name: "Intersecting a ray with a non-cubic bounding box", Min: tuple.Point(5.0, -2.0, 0.0), Max: tuple.Point(11.0, 4.0, 7.0), Origin: tuple.Point(9.0, -1.0, -8.0), Direction: tuple.Vector(2.0, 4.0, 6.0), Expected: false},
+		{Name: "Intersecting a ray with a non-cubic bounding box", Min: tuple.Point(5.0, -2.0, 0.0), Max: tuple.Point(11.0, 4.0, 7.0), Origin: tuple.Point(8.0, 3.0, -4.0), Direction: tuple.Vector(6.0, 2.0, 4.0), Expected: false},
+		{Name: "Intersecting a ray with a non-cubic bounding box", Min: tuple.Point(5.0, -2.0, 0.0), Max: tuple.Point(11.0, 4.0, 7.0), Origin: tuple.Point(9.0, -1.0, -2.0), Direction: tuple.Vector(4.0, 6.0, 2.0), Expected: false},
+		{Name: "Intersecting a ray with a non-cubic bounding box", Min: tuple.Point(5.0, -2.0, 0.0), Max: tuple.Point(11.0, 4.0, 7.0), Origin: tuple.Point(4.0, 0.0, 9.0), Direction: tuple.Vector(0.0, 0.0, -1.0), Expected: false},
+		{Name: "Intersecting a ray with a non-cubic bounding box", Min: tuple.Point(5.0, -2.0, 0.0), Max: tuple.Point(11.0, 4.0, 7.0), Origin: tuple.Point(8.0, 6.0, -1.0), Direction: tuple.Vector(0.0, -1.0, 0.0), Expected: false},
+		{Name: "Intersecting a ray with a non-cubic bounding box", Min: tuple.Point(5.0, -2.0, 0.0), Max: tuple.Point(11.0, 4.0, 7.0), Origin: tuple.Point(12.0, 5.0, 4.0), Direction: tuple.Vector(-1.0, 0.0, 0.0), Expected: false},
+
+		{Name: "Intersecting a ray with an empty bounding box", Min: tuple.Point(1.0, 1.0, 1.0), Max: tuple.Point(-1.0, -1.0, -1.0), Origin: tuple.Point(0.0, 0.0, -5.0), Direction: tuple.Vector(0.0, 0.0, 1.0), Expected: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			// Given
+			b := shape.NewBounds(tuple.Point(-1.0, -1.0, -1.0), tuple.Point(1.0, 1.0, 1.0))
+			if test.Min != (tuple.Tuple{}) {
+				b = shape.NewBounds(test.Min, test.Max)
+			}
+			r := ray.New(test.Origin, test.Direction.Normalize())
+
+			// Then
+			assert.Equal(t, test.Expected, b.Intersects(r))
+		})
+	}
+}
+
+func TestBoundsSplit(t *testing.T) {
+	tests := []struct {
+		Name               string
+		Min, Max           tuple.Tuple
+		LeftMin, LeftMax   tuple.Tuple
+		RightMin, RightMax tuple.Tuple
+	}{
+		{
+			Name:     "Splitting a perfect cube",
+			Min:      tuple.Point(-1.0, -4.0, -5.0),
+			Max:      tuple.Point(9.0, 6.0, 5.0),
+			LeftMin:  tuple.Point(-1.0, -4.0, -5.0),
+			LeftMax:  tuple.Point(4.0, 6.0, 5.0),
+			RightMin: tuple.Point(4.0, -4.0, -5.0),
+			RightMax: tuple.Point(9.0, 6.0, 5.0),
+		},
+
+		{
+			Name:     "Splitting an x-wide box",
+			Min:      tuple.Point(-1.0, -2.0, -3.0),
+			Max:      tuple.Point(9.0, 5.5, 3.0),
+			LeftMin:  tuple.Point(-1.0, -2.0, -3.0),
+			LeftMax:  tuple.Point(4.0, 5.5, 3.0),
+			RightMin: tuple.Point(4.0, -2.0, -3.0),
+			RightMax: tuple.Point(9.0, 5.5, 3.0),
+		},
+
+		{
+			Name:     "Splitting a y-wide box",
+			Min:      tuple.Point(-1.0, -2.0, -3.0),
+			Max:      tuple.Point(5.0, 8.0, 3.0),
+			LeftMin:  tuple.Point(-1.0, -2.0, -3.0),
+			LeftMax:  tuple.Point(5.0, 3.0, 3.0),
+			RightMin: tuple.Point(-1.0, 3.0, -3.0),
+			RightMax: tuple.Point(5.0, 8.0, 3.0),
+		},
+
+		{
+			Name:     "Splitting a z-wide box",
+			Min:      tuple.Point(-1.0, -2.0, -3.0),
+			Max:      tuple.Point(5.0, 3.0, 7.0),
+			LeftMin:  tuple.Point(-1.0, -2.0, -3.0),
+			LeftMax:  tuple.Point(5.0, 3.0, 2.0),
+			RightMin: tuple.Point(-1.0, -2.0, 2.0),
+			RightMax: tuple.Point(5.0, 3.0, 7.0),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			// Given
+			b := shape.NewBounds(test.Min, test.Max)
+
+			// When
+			left, right := b.Split()
+
+			// Then
+			assert.Equal(t, test.LeftMin, left.Min())
+			assert.Equal(t, test.LeftMax, left.Max())
+			assert.Equal(t, test.RightMin, right.Min())
+			assert.Equal(t, test.RightMax, right.Max())
+		})
+	}
+}
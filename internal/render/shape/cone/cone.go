@@ -0,0 +1,167 @@
+package cone
+
+import (
+	"math"
+
+	mathUtil "github.com/tyz910/ray-tracer-challenge/internal/math"
+	"github.com/tyz910/ray-tracer-challenge/internal/math/tuple"
+	"github.com/tyz910/ray-tracer-challenge/internal/render/ray"
+	"github.com/tyz910/ray-tracer-challenge/internal/render/shape"
+)
+
+// Cone represents a double-napped cone centered on the y axis, with its tips meeting at the origin.
+// It may be truncated at the minimum and maximum y values, and optionally closed with end caps.
+type Cone struct {
+	shape.Base
+
+	minimum, maximum float64
+	closed           bool
+}
+
+// New creates new infinite open cone.
+func New() *Cone {
+	c := &Cone{
+		minimum: math.Inf(-1),
+		maximum: math.Inf(1),
+	}
+	c.Base = shape.NewBase(c)
+
+	return c
+}
+
+// Minimum returns the y value where the cone is truncated from below (exclusive).
+func (c *Cone) Minimum() float64 {
+	return c.minimum
+}
+
+// SetMinimum changes the y value where the cone is truncated from below.
+func (c *Cone) SetMinimum(minimum float64) {
+	c.minimum = minimum
+	shape.UpdateParentBounds(c.Parent())
+}
+
+// Maximum returns the y value where the cone is truncated from above (exclusive).
+func (c *Cone) Maximum() float64 {
+	return c.maximum
+}
+
+// SetMaximum changes the y value where the cone is truncated from above.
+func (c *Cone) SetMaximum(maximum float64) {
+	c.maximum = maximum
+	shape.UpdateParentBounds(c.Parent())
+}
+
+// Closed checks whether the cone is capped at its ends.
+func (c *Cone) Closed() bool {
+	return c.closed
+}
+
+// SetClosed changes whether the cone is capped at its ends.
+func (c *Cone) SetClosed(closed bool) {
+	c.closed = closed
+}
+
+// LocalIntersect appends the intersections where the ray intersects the cone in object space to xs.
+func (c *Cone) LocalIntersect(r ray.Ray, xs shape.Intersections) shape.Intersections {
+	o := r.Origin()
+	d := r.Direction()
+
+	a := d.X()*d.X() - d.Y()*d.Y() + d.Z()*d.Z()
+	b := 2.0*o.X()*d.X() - 2.0*o.Y()*d.Y() + 2.0*o.Z()*d.Z()
+	c2 := o.X()*o.X() - o.Y()*o.Y() + o.Z()*o.Z()
+
+	if mathUtil.Equals(a, 0.0) {
+		// the ray is parallel to one of the cone's halves, so it intersects the other half once
+		if !mathUtil.Equals(b, 0.0) {
+			t := -c2 / (2.0 * b)
+			xs = c.appendWithinBounds(r, xs, t)
+		}
+
+		return c.intersectCaps(r, xs)
+	}
+
+	disc := b*b - 4.0*a*c2
+
+	// the ray does not intersect the cone
+	if disc < 0.0 {
+		return xs
+	}
+
+	t0 := (-b - math.Sqrt(disc)) / (2.0 * a)
+	t1 := (-b + math.Sqrt(disc)) / (2.0 * a)
+	if t0 > t1 {
+		t0, t1 = t1, t0
+	}
+
+	xs = c.appendWithinBounds(r, xs, t0)
+	xs = c.appendWithinBounds(r, xs, t1)
+
+	return c.intersectCaps(r, xs)
+}
+
+// appendWithinBounds adds the intersection at t if it lies between the minimum and maximum of the cone.
+func (c *Cone) appendWithinBounds(r ray.Ray, xs shape.Intersections, t float64) shape.Intersections {
+	y := r.Origin().Y() + t*r.Direction().Y()
+	if c.minimum < y && y < c.maximum {
+		xs = append(xs, shape.NewIntersection(t, c))
+	}
+
+	return xs
+}
+
+// intersectCaps adds the intersections of the ray with the end caps of the closed cone.
+func (c *Cone) intersectCaps(r ray.Ray, xs shape.Intersections) shape.Intersections {
+	if !c.closed || mathUtil.Equals(r.Direction().Y(), 0.0) {
+		return xs
+	}
+
+	// check for an intersection with the lower end cap
+	t := (c.minimum - r.Origin().Y()) / r.Direction().Y()
+	if checkCap(r, t, c.minimum) {
+		xs = append(xs, shape.NewIntersection(t, c))
+	}
+
+	// check for an intersection with the upper end cap
+	t = (c.maximum - r.Origin().Y()) / r.Direction().Y()
+	if checkCap(r, t, c.maximum) {
+		xs = append(xs, shape.NewIntersection(t, c))
+	}
+
+	return xs
+}
+
+// LocalNormalAt returns the normal on the cone at the given point in object space.
+func (c *Cone) LocalNormalAt(p tuple.Tuple, _ *shape.Intersection) tuple.Tuple {
+	// the square of the distance from the y axis
+	dist := p.X()*p.X() + p.Z()*p.Z()
+
+	if dist < p.Y()*p.Y() && p.Y() >= c.maximum-mathUtil.Epsilon {
+		return tuple.Vector(0.0, 1.0, 0.0)
+	} else if dist < p.Y()*p.Y() && p.Y() <= c.minimum+mathUtil.Epsilon {
+		return tuple.Vector(0.0, -1.0, 0.0)
+	}
+
+	y := math.Sqrt(dist)
+	if p.Y() > 0.0 {
+		y = -y
+	}
+
+	return tuple.Vector(p.X(), y, p.Z())
+}
+
+// checkCap checks whether the intersection at t is within the radius of the cone at the cap's y value.
+// The radius of the cone equals the absolute value of y.
+func checkCap(r ray.Ray, t, y float64) bool {
+	x := r.Origin().X() + t*r.Direction().X()
+	z := r.Origin().Z() + t*r.Direction().Z()
+
+	return (x*x + z*z) <= y*y
+}
+
+// Bounds returns the bounding box of the cone in object space.
+// The radius of the cone at any y equals |y|, so the box is as wide as the farthest end.
+func (c *Cone) Bounds() shape.Bounds {
+	limit := math.Max(math.Abs(c.minimum), math.Abs(c.maximum))
+
+	return shape.NewBounds(tuple.Point(-limit, c.minimum, -limit), tuple.Point(limit, c.maximum, limit))
+}
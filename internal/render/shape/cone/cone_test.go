@@ -0,0 +1,151 @@
+package cone_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/tyz910/ray-tracer-challenge/internal/math/tuple"
+	"github.com/tyz910/ray-tracer-challenge/internal/render/ray"
+	"github.com/tyz910/ray-tracer-challenge/internal/render/shape/cone"
+)
+
+// Intersecting a cone with a ray
+func TestIntersect(t *testing.T) {
+	tests := []struct {
+		Origin    tuple.Tuple
+		Direction tuple.Tuple
+		T0, T1    float64
+	}{
+		{Origin: tuple.Point(0.0, 0.0, -5.0), Direction: tuple.Vector(0.0, 0.0, 1.0), T0: 5.0, T1: 5.0},
+		{Origin: tuple.Point(0.0, 0.0, -5.0), Direction: tuple.Vector(1.0, 1.0, 1.0), T0: 8.66025, T1: 8.66025},
+		{Origin: tuple.Point(1.0, 1.0, -5.0), Direction: tuple.Vector(-0.5, -1.0, 1.0), T0: 4.55006, T1: 49.44994},
+	}
+
+	for _, test := range tests {
+		t.Run("Intersecting a cone with a ray", func(t *testing.T) {
+			// Given
+			c := cone.New()
+			r := ray.New(test.Origin, test.Direction.Normalize())
+
+			// When
+			xs := c.LocalIntersect(r, nil)
+
+			// Then
+			assert.Equal(t, 2, len(xs))
+			assert.InDelta(t, test.T0, xs[0].T(), 0.0001)
+			assert.InDelta(t, test.T1, xs[1].T(), 0.0001)
+		})
+	}
+}
+
+// Intersecting a cone with a ray parallel to one of its halves
+func TestIntersectParallel(t *testing.T) {
+	// Given
+	c := cone.New()
+	r := ray.New(tuple.Point(0.0, 0.0, -1.0), tuple.Vector(0.0, 1.0, 1.0).Normalize())
+
+	// When
+	xs := c.LocalIntersect(r, nil)
+
+	// Then
+	assert.Equal(t, 1, len(xs))
+	assert.InDelta(t, 0.35355, xs[0].T(), 0.00001)
+}
+
+// Intersecting a cone's end caps
+func TestIntersectCaps(t *testing.T) {
+	tests := []struct {
+		Origin    tuple.Tuple
+		Direction tuple.Tuple
+		Count     int
+	}{
+		{Origin: tuple.Point(0.0, 0.0, -5.0), Direction: tuple.Vector(0.0, 1.0, 0.0), Count: 0},
+		{Origin: tuple.Point(0.0, 0.0, -0.25), Direction: tuple.Vector(0.0, 1.0, 1.0), Count: 2},
+		{Origin: tuple.Point(0.0, 0.0, -0.25), Direction: tuple.Vector(0.0, 1.0, 0.0), Count: 4},
+	}
+
+	for _, test := range tests {
+		t.Run("Intersecting a cone's end caps", func(t *testing.T) {
+			// Given
+			c := cone.New()
+			c.SetMinimum(-0.5)
+			c.SetMaximum(0.5)
+			c.SetClosed(true)
+			r := ray.New(test.Origin, test.Direction.Normalize())
+
+			// When
+			xs := c.LocalIntersect(r, nil)
+
+			// Then
+			assert.Equal(t, test.Count, len(xs))
+		})
+	}
+}
+
+// Computing the normal vector on a cone
+func TestNormal(t *testing.T) {
+	tests := []struct {
+		Point  tuple.Tuple
+		Normal tuple.Tuple
+	}{
+		{Point: tuple.Point(0.0, 0.0, 0.0), Normal: tuple.Vector(0.0, 0.0, 0.0)},
+		{Point: tuple.Point(1.0, 1.0, 1.0), Normal: tuple.Vector(1.0, -math.Sqrt(2.0), 1.0)},
+		{Point: tuple.Point(-1.0, -1.0, 0.0), Normal: tuple.Vector(-1.0, 1.0, 0.0)},
+	}
+
+	for _, test := range tests {
+		t.Run("Computing the normal vector on a cone", func(t *testing.T) {
+			// Given
+			c := cone.New()
+
+			// When
+			n := c.LocalNormalAt(test.Point, nil)
+
+			// Then
+			assert.True(t, n.Equal(test.Normal))
+		})
+	}
+}
+
+func TestBounds(t *testing.T) {
+	tests := []struct {
+		Name     string
+		Minimum  float64
+		Maximum  float64
+		Min, Max tuple.Tuple
+	}{
+		{
+			Name:    "An unbounded cone has a bounding box",
+			Minimum: math.Inf(-1),
+			Maximum: math.Inf(1),
+			Min:     tuple.Point(math.Inf(-1), math.Inf(-1), math.Inf(-1)),
+			Max:     tuple.Point(math.Inf(1), math.Inf(1), math.Inf(1)),
+		},
+
+		{
+			Name:    "A bounded cone has a bounding box",
+			Minimum: -5.0,
+			Maximum: 3.0,
+			Min:     tuple.Point(-5.0, -5.0, -5.0),
+			Max:     tuple.Point(5.0, 3.0, 5.0),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			// Given
+			c := cone.New()
+			c.SetMinimum(test.Minimum)
+			c.SetMaximum(test.Maximum)
+
+			// When
+			b := c.Bounds()
+
+			// Then
+			assert.Equal(t, test.Min, b.Min())
+			assert.Equal(t, test.Max, b.Max())
+		})
+	}
+}
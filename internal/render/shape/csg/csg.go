@@ -0,0 +1,159 @@
+package csg
+
+import (
+	"github.com/tyz910/ray-tracer-challenge/internal/math/tuple"
+	"github.com/tyz910/ray-tracer-challenge/internal/render/ray"
+	"github.com/tyz910/ray-tracer-challenge/internal/render/shape"
+)
+
+// Operation is a set operation used to combine two shapes.
+type Operation int
+
+const (
+	// Union combines two shapes, preserving all of their exterior surfaces.
+	Union Operation = iota
+
+	// Intersection preserves the portion of the shapes where they overlap.
+	Intersection
+
+	// Difference preserves the portion of the left shape not overlapped by the right shape.
+	Difference
+)
+
+func (op Operation) String() string {
+	switch op {
+	case Union:
+		return "union"
+	case Intersection:
+		return "intersection"
+	case Difference:
+		return "difference"
+	}
+
+	return "unknown"
+}
+
+// CSG represents constructive solid geometry: a shape composed of two shapes combined with a set operation.
+type CSG struct {
+	shape.Base
+
+	operation   Operation
+	left, right shape.Shape
+
+	// bounds of both operands in the space of the CSG shape
+	bounds shape.Bounds
+}
+
+// New creates new CSG shape combining left and right shapes with the given operation.
+func New(operation Operation, left, right shape.Shape) *CSG {
+	c := &CSG{
+		operation: operation,
+		left:      left,
+		right:     right,
+	}
+	c.Base = shape.NewBase(c)
+
+	left.SetParent(c)
+	right.SetParent(c)
+	c.UpdateBounds()
+
+	return c
+}
+
+// Operation returns the set operation of the CSG shape.
+func (c *CSG) Operation() Operation {
+	return c.operation
+}
+
+// Left returns the left operand of the CSG shape.
+func (c *CSG) Left() shape.Shape {
+	return c.left
+}
+
+// Right returns the right operand of the CSG shape.
+func (c *CSG) Right() shape.Shape {
+	return c.right
+}
+
+// Includes checks whether the shape is one of the descendants of the CSG shape.
+func (c *CSG) Includes(s shape.Shape) bool {
+	return shape.Includes(c.left, s) || shape.Includes(c.right, s)
+}
+
+// Bounds returns the bounding box containing both operands of the CSG shape.
+func (c *CSG) Bounds() shape.Bounds {
+	return c.bounds
+}
+
+// UpdateBounds recomputes the bounding box of the CSG shape after one of the operands has changed.
+func (c *CSG) UpdateBounds() {
+	c.bounds = c.left.ParentSpaceBounds().Merge(c.right.ParentSpaceBounds())
+
+	shape.UpdateParentBounds(c.Parent())
+}
+
+// Divide builds bounding volume hierarchies from both operands of the CSG shape.
+func (c *CSG) Divide(threshold int) {
+	shape.Divide(c.left, threshold)
+	shape.Divide(c.right, threshold)
+}
+
+// LocalIntersect appends the intersections of the ray with both operands which lie on the surface of the CSG shape to xs.
+func (c *CSG) LocalIntersect(r ray.Ray, xs shape.Intersections) shape.Intersections {
+	if !c.bounds.Intersects(r) {
+		return xs
+	}
+
+	start := len(xs)
+	xs = c.left.Intersect(r, xs)
+	xs = c.right.Intersect(r, xs)
+	xs[start:].Sort()
+
+	return append(xs[:start], c.FilterIntersections(xs[start:])...)
+}
+
+// LocalNormalAt panics, because normals are always computed on the operands of the CSG shape.
+func (c *CSG) LocalNormalAt(_ tuple.Tuple, _ *shape.Intersection) tuple.Tuple {
+	panic("local normal of a csg is undefined")
+}
+
+// FilterIntersections returns the sorted intersections which lie on the surface of the CSG shape.
+func (c *CSG) FilterIntersections(xs shape.Intersections) shape.Intersections {
+	// begin outside of both operands
+	inLeft := false
+	inRight := false
+
+	result := shape.Intersections{}
+	for _, i := range xs {
+		leftHit := shape.Includes(c.left, i.Object())
+
+		if IntersectionAllowed(c.operation, leftHit, inLeft, inRight) {
+			result = append(result, i)
+		}
+
+		// depending on which operand was hit, toggle either inLeft or inRight
+		if leftHit {
+			inLeft = !inLeft
+		} else {
+			inRight = !inRight
+		}
+	}
+
+	return result
+}
+
+// IntersectionAllowed checks whether the intersection is preserved by the operation.
+// The leftHit flag tells whether the left operand was hit, while inLeft and inRight tell
+// whether the hit occurs inside the left and the right operand respectively.
+func IntersectionAllowed(op Operation, leftHit, inLeft, inRight bool) bool {
+	switch op {
+	case Union:
+		return (leftHit && !inRight) || (!leftHit && !inLeft)
+	case Intersection:
+		return (leftHit && inRight) || (!leftHit && inLeft)
+	case Difference:
+		return (leftHit && !inRight) || (!leftHit && inLeft)
+	}
+
+	return false
+}
@@ -0,0 +1,257 @@
+package csg_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tyz910/ray-tracer-challenge/internal/math/matrix"
+	"github.com/tyz910/ray-tracer-challenge/internal/math/tuple"
+	"github.com/tyz910/ray-tracer-challenge/internal/render/ray"
+	"github.com/tyz910/ray-tracer-challenge/internal/render/shape"
+	"github.com/tyz910/ray-tracer-challenge/internal/render/shape/csg"
+	"github.com/tyz910/ray-tracer-challenge/internal/render/shape/cube"
+	"github.com/tyz910/ray-tracer-challenge/internal/render/shape/group"
+	"github.com/tyz910/ray-tracer-challenge/internal/render/shape/sphere"
+)
+
+// CSG is created with an operation and two shapes
+func TestCreateCSG(t *testing.T) {
+	// Given
+	s1 := sphere.New()
+	s2 := cube.New()
+
+	// When
+	c := csg.New(csg.Union, s1, s2)
+
+	// Then
+	assert.Equal(t, csg.Union, c.Operation())
+	assert.Equal(t, "union", c.Operation().String())
+	assert.Equal(t, s1, c.Left())
+	assert.Equal(t, s2, c.Right())
+	assert.Equal(t, c, s1.Parent())
+	assert.Equal(t, c, s2.Parent())
+}
+
+// Evaluating the rule for a CSG operation
+func TestIntersectionAllowed(t *testing.T) {
+	tests := []struct {
+		Op                       csg.Operation
+		LeftHit, InLeft, InRight bool
+		Result                   bool
+	}{
+		{csg.Union, true, true, true, false},
+		{csg.Union, true, true, false, true},
+		{csg.Union, true, false, true, false},
+		{csg.Union, true, false, false, true},
+		{csg.Union, false, true, true, false},
+		{csg.Union, false, true, false, false},
+		{csg.Union, false, false, true, true},
+		{csg.Union, false, false, false, true},
+
+		{csg.Intersection, true, true, true, true},
+		{csg.Intersection, true, true, false, false},
+		{csg.Intersection, true, false, true, true},
+		{csg.Intersection, true, false, false, false},
+		{csg.Intersection, false, true, true, true},
+		{csg.Intersection, false, true, false, true},
+		{csg.Intersection, false, false, true, false},
+		{csg.Intersection, false, false, false, false},
+
+		{csg.Difference, true, true, true, false},
+		{csg.Difference, true, true, false, true},
+		{csg.Difference, true, false, true, false},
+		{csg.Difference, true, false, false, true},
+		{csg.Difference, false, true, true, true},
+		{csg.Difference, false, true, false, true},
+		{csg.Difference, false, false, true, false},
+		{csg.Difference, false, false, false, false},
+	}
+
+	for _, test := range tests {
+		name := fmt.Sprintf("%v lhit=%v inl=%v inr=%v", test.Op, test.LeftHit, test.InLeft, test.InRight)
+		t.Run(name, func(t *testing.T) {
+			// When
+			result := csg.IntersectionAllowed(test.Op, test.LeftHit, test.InLeft, test.InRight)
+
+			// Then
+			assert.Equal(t, test.Result, result)
+		})
+	}
+}
+
+// Filtering a list of intersections
+func TestFilterIntersections(t *testing.T) {
+	tests := []struct {
+		Op     csg.Operation
+		X0, X1 int
+	}{
+		{Op: csg.Union, X0: 0, X1: 3},
+		{Op: csg.Intersection, X0: 1, X1: 2},
+		{Op: csg.Difference, X0: 0, X1: 1},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Op.String(), func(t *testing.T) {
+			// Given
+			s1 := sphere.New()
+			s2 := cube.New()
+			c := csg.New(test.Op, s1, s2)
+			xs := shape.Intersections{
+				shape.NewIntersection(1.0, s1),
+				shape.NewIntersection(2.0, s2),
+				shape.NewIntersection(3.0, s1),
+				shape.NewIntersection(4.0, s2),
+			}
+
+			// When
+			result := c.FilterIntersections(xs)
+
+			// Then
+			assert.Equal(t, 2, len(result))
+			assert.Equal(t, xs[test.X0], result[0])
+			assert.Equal(t, xs[test.X1], result[1])
+		})
+	}
+}
+
+// Filtering intersections with nested children
+func TestFilterIntersectionsNested(t *testing.T) {
+	// Given
+	s1 := sphere.New()
+	g := group.New()
+	g.AddChild(s1)
+	s2 := cube.New()
+	c := csg.New(csg.Difference, g, s2)
+	xs := shape.Intersections{
+		shape.NewIntersection(1.0, s1),
+		shape.NewIntersection(2.0, s2),
+		shape.NewIntersection(3.0, s1),
+		shape.NewIntersection(4.0, s2),
+	}
+
+	// When
+	result := c.FilterIntersections(xs)
+
+	// Then
+	assert.Equal(t, shape.Intersections{xs[0], xs[1]}, result)
+}
+
+// A ray misses a CSG object
+func TestIntersectMiss(t *testing.T) {
+	// Given
+	c := csg.New(csg.Union, sphere.New(), cube.New())
+	r := ray.New(tuple.Point(0.0, 2.0, -5.0), tuple.Vector(0.0, 0.0, 1.0))
+
+	// When
+	xs := c.LocalIntersect(r, nil)
+
+	// Then
+	assert.Empty(t, xs)
+}
+
+// A ray hits a CSG object
+func TestIntersectHit(t *testing.T) {
+	// Given
+	s1 := sphere.New()
+	s2 := sphere.New()
+	s2.SetTransform(matrix.Translation(0.0, 0.0, 0.5))
+	c := csg.New(csg.Union, s1, s2)
+	r := ray.New(tuple.Point(0.0, 0.0, -5.0), tuple.Vector(0.0, 0.0, 1.0))
+
+	// When
+	xs := c.LocalIntersect(r, nil)
+
+	// Then
+	assert.Equal(t, 2, len(xs))
+	assert.Equal(t, 4.0, xs[0].T())
+	assert.Equal(t, s1, xs[0].Object())
+	assert.Equal(t, 6.5, xs[1].T())
+	assert.Equal(t, s2, xs[1].Object())
+}
+
+// A CSG shape has a bounding box that contains its children
+func TestBounds(t *testing.T) {
+	// Given
+	left := sphere.New()
+	right := sphere.New()
+	right.SetTransform(matrix.Translation(2.0, 3.0, 4.0))
+	c := csg.New(csg.Difference, left, right)
+
+	// When
+	b := c.Bounds()
+
+	// Then
+	assert.Equal(t, tuple.Point(-1.0, -1.0, -1.0), b.Min())
+	assert.Equal(t, tuple.Point(3.0, 4.0, 5.0), b.Max())
+}
+
+func TestIntersectBounds(t *testing.T) {
+	tests := []struct {
+		Name     string
+		Ray      ray.Ray
+		Expected bool
+	}{
+		{
+			Name:     "Intersecting ray+csg doesn't test children if box is missed",
+			Ray:      ray.New(tuple.Point(0.0, 0.0, -5.0), tuple.Vector(0.0, 1.0, 0.0)),
+			Expected: false,
+		},
+
+		{
+			Name:     "Intersecting ray+csg tests children if box is hit",
+			Ray:      ray.New(tuple.Point(0.0, 0.0, -5.0), tuple.Vector(0.0, 0.0, 1.0)),
+			Expected: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			// Given
+			left := shape.NewTestShape()
+			right := shape.NewTestShape()
+			c := csg.New(csg.Difference, left, right)
+
+			// When
+			c.Intersect(test.Ray, nil)
+
+			// Then
+			assert.Equal(t, test.Expected, left.SavedRay() != ray.Ray{})
+			assert.Equal(t, test.Expected, right.SavedRay() != ray.Ray{})
+		})
+	}
+}
+
+// Subdividing a CSG shape subdivides its children
+func TestDivide(t *testing.T) {
+	// Given
+	s1 := sphere.New()
+	s1.SetTransform(matrix.Translation(-1.5, 0.0, 0.0))
+	s2 := sphere.New()
+	s2.SetTransform(matrix.Translation(1.5, 0.0, 0.0))
+	left := group.New()
+	left.AddChild(s1, s2)
+
+	s3 := sphere.New()
+	s3.SetTransform(matrix.Translation(0.0, 0.0, -1.5))
+	s4 := sphere.New()
+	s4.SetTransform(matrix.Translation(0.0, 0.0, 1.5))
+	right := group.New()
+	right.AddChild(s3, s4)
+
+	c := csg.New(csg.Difference, left, right)
+
+	// When
+	c.Divide(1)
+
+	// Then
+	require.Equal(t, 2, len(left.Children()))
+	assert.Equal(t, []shape.Shape{s1}, left.Children()[0].(*group.Group).Children())
+	assert.Equal(t, []shape.Shape{s2}, left.Children()[1].(*group.Group).Children())
+
+	require.Equal(t, 2, len(right.Children()))
+	assert.Equal(t, []shape.Shape{s3}, right.Children()[0].(*group.Group).Children())
+	assert.Equal(t, []shape.Shape{s4}, right.Children()[1].(*group.Group).Children())
+}
@@ -0,0 +1,83 @@
+package cube
+
+import (
+	"math"
+
+	mathUtil "github.com/tyz910/ray-tracer-challenge/internal/math"
+	"github.com/tyz910/ray-tracer-challenge/internal/math/tuple"
+	"github.com/tyz910/ray-tracer-challenge/internal/render/ray"
+	"github.com/tyz910/ray-tracer-challenge/internal/render/shape"
+)
+
+// Cube represents an axis-aligned cube centered at the origin, extending from -1 to 1 along each axis.
+type Cube struct {
+	shape.Base
+}
+
+// New creates new cube.
+func New() *Cube {
+	c := &Cube{}
+	c.Base = shape.NewBase(c)
+
+	return c
+}
+
+// LocalIntersect appends the intersections where the ray intersects the cube in object space to xs.
+// The cube is treated as the intersection of three pairs of parallel planes (slabs).
+func (c *Cube) LocalIntersect(r ray.Ray, xs shape.Intersections) shape.Intersections {
+	xtMin, xtMax := checkAxis(r.Origin().X(), r.Direction().X())
+	ytMin, ytMax := checkAxis(r.Origin().Y(), r.Direction().Y())
+	ztMin, ztMax := checkAxis(r.Origin().Z(), r.Direction().Z())
+
+	tMin := math.Max(xtMin, math.Max(ytMin, ztMin))
+	tMax := math.Min(xtMax, math.Min(ytMax, ztMax))
+
+	// the ray misses the cube
+	if tMin > tMax {
+		return xs
+	}
+
+	return append(xs,
+		shape.NewIntersection(tMin, c),
+		shape.NewIntersection(tMax, c),
+	)
+}
+
+// LocalNormalAt returns the normal on the cube at the given point in object space.
+// The normal points along the axis with the largest absolute component of the point.
+func (c *Cube) LocalNormalAt(p tuple.Tuple, _ *shape.Intersection) tuple.Tuple {
+	maxC := math.Max(math.Abs(p.X()), math.Max(math.Abs(p.Y()), math.Abs(p.Z())))
+
+	if maxC == math.Abs(p.X()) {
+		return tuple.Vector(p.X(), 0.0, 0.0)
+	} else if maxC == math.Abs(p.Y()) {
+		return tuple.Vector(0.0, p.Y(), 0.0)
+	}
+
+	return tuple.Vector(0.0, 0.0, p.Z())
+}
+
+// checkAxis returns the t values where the ray intersects the pair of planes at -1 and 1 along a single axis.
+func checkAxis(origin, direction float64) (tMin, tMax float64) {
+	tMinNumerator := -1.0 - origin
+	tMaxNumerator := 1.0 - origin
+
+	if math.Abs(direction) >= mathUtil.Epsilon {
+		tMin = tMinNumerator / direction
+		tMax = tMaxNumerator / direction
+	} else {
+		tMin = tMinNumerator * math.Inf(1)
+		tMax = tMaxNumerator * math.Inf(1)
+	}
+
+	if tMin > tMax {
+		tMin, tMax = tMax, tMin
+	}
+
+	return
+}
+
+// Bounds returns the bounding box of the cube in object space.
+func (c *Cube) Bounds() shape.Bounds {
+	return shape.NewBounds(tuple.Point(-1.0, -1.0, -1.0), tuple.Point(1.0, 1.0, 1.0))
+}
@@ -0,0 +1,117 @@
+package cube_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/tyz910/ray-tracer-challenge/internal/math/tuple"
+	"github.com/tyz910/ray-tracer-challenge/internal/render/ray"
+	"github.com/tyz910/ray-tracer-challenge/internal/render/shape/cube"
+)
+
+// A ray intersects a cube
+func TestIntersect(t *testing.T) {
+	tests := []struct {
+		Name      string
+		Origin    tuple.Tuple
+		Direction tuple.Tuple
+		T1, T2    float64
+	}{
+		{Name: "+x", Origin: tuple.Point(5.0, 0.5, 0.0), Direction: tuple.Vector(-1.0, 0.0, 0.0), T1: 4.0, T2: 6.0},
+		{Name: "-x", Origin: tuple.Point(-5.0, 0.5, 0.0), Direction: tuple.Vector(1.0, 0.0, 0.0), T1: 4.0, T2: 6.0},
+		{Name: "+y", Origin: tuple.Point(0.5, 5.0, 0.0), Direction: tuple.Vector(0.0, -1.0, 0.0), T1: 4.0, T2: 6.0},
+		{Name: "-y", Origin: tuple.Point(0.5, -5.0, 0.0), Direction: tuple.Vector(0.0, 1.0, 0.0), T1: 4.0, T2: 6.0},
+		{Name: "+z", Origin: tuple.Point(0.5, 0.0, 5.0), Direction: tuple.Vector(0.0, 0.0, -1.0), T1: 4.0, T2: 6.0},
+		{Name: "-z", Origin: tuple.Point(0.5, 0.0, -5.0), Direction: tuple.Vector(0.0, 0.0, 1.0), T1: 4.0, T2: 6.0},
+		{Name: "inside", Origin: tuple.Point(0.0, 0.5, 0.0), Direction: tuple.Vector(0.0, 0.0, 1.0), T1: -1.0, T2: 1.0},
+	}
+
+	for _, test := range tests {
+		t.Run("A ray intersects a cube from "+test.Name, func(t *testing.T) {
+			// Given
+			c := cube.New()
+			r := ray.New(test.Origin, test.Direction)
+
+			// When
+			xs := c.LocalIntersect(r, nil)
+
+			// Then
+			assert.Equal(t, 2, len(xs))
+			assert.Equal(t, test.T1, xs[0].T())
+			assert.Equal(t, test.T2, xs[1].T())
+		})
+	}
+}
+
+// A ray misses a cube
+func TestIntersectMiss(t *testing.T) {
+	tests := []struct {
+		Origin    tuple.Tuple
+		Direction tuple.Tuple
+	}{
+		{Origin: tuple.Point(-2.0, 0.0, 0.0), Direction: tuple.Vector(0.2673, 0.5345, 0.8018)},
+		{Origin: tuple.Point(0.0, -2.0, 0.0), Direction: tuple.Vector(0.8018, 0.2673, 0.5345)},
+		{Origin: tuple.Point(0.0, 0.0, -2.0), Direction: tuple.Vector(0.5345, 0.8018, 0.2673)},
+		{Origin: tuple.Point(2.0, 0.0, 2.0), Direction: tuple.Vector(0.0, 0.0, -1.0)},
+		{Origin: tuple.Point(0.0, 2.0, 2.0), Direction: tuple.Vector(0.0, -1.0, 0.0)},
+		{Origin: tuple.Point(2.0, 2.0, 0.0), Direction: tuple.Vector(-1.0, 0.0, 0.0)},
+	}
+
+	for _, test := range tests {
+		t.Run("A ray misses a cube", func(t *testing.T) {
+			// Given
+			c := cube.New()
+			r := ray.New(test.Origin, test.Direction)
+
+			// When
+			xs := c.LocalIntersect(r, nil)
+
+			// Then
+			assert.Equal(t, 0, len(xs))
+		})
+	}
+}
+
+// The normal on the surface of a cube
+func TestNormal(t *testing.T) {
+	tests := []struct {
+		Point  tuple.Tuple
+		Normal tuple.Tuple
+	}{
+		{Point: tuple.Point(1.0, 0.5, -0.8), Normal: tuple.Vector(1.0, 0.0, 0.0)},
+		{Point: tuple.Point(-1.0, -0.2, 0.9), Normal: tuple.Vector(-1.0, 0.0, 0.0)},
+		{Point: tuple.Point(-0.4, 1.0, -0.1), Normal: tuple.Vector(0.0, 1.0, 0.0)},
+		{Point: tuple.Point(0.3, -1.0, -0.7), Normal: tuple.Vector(0.0, -1.0, 0.0)},
+		{Point: tuple.Point(-0.6, 0.3, 1.0), Normal: tuple.Vector(0.0, 0.0, 1.0)},
+		{Point: tuple.Point(0.4, 0.4, -1.0), Normal: tuple.Vector(0.0, 0.0, -1.0)},
+		{Point: tuple.Point(1.0, 1.0, 1.0), Normal: tuple.Vector(1.0, 0.0, 0.0)},
+		{Point: tuple.Point(-1.0, -1.0, -1.0), Normal: tuple.Vector(-1.0, 0.0, 0.0)},
+	}
+
+	for _, test := range tests {
+		t.Run("The normal on the surface of a cube", func(t *testing.T) {
+			// Given
+			c := cube.New()
+
+			// When
+			n := c.LocalNormalAt(test.Point, nil)
+
+			// Then
+			assert.True(t, n.Equal(test.Normal))
+		})
+	}
+}
+
+// A cube has a bounding box
+func TestBounds(t *testing.T) {
+	// Given
+	c := cube.New()
+
+	// When
+	b := c.Bounds()
+
+	// Then
+	assert.Equal(t, tuple.Point(-1.0, -1.0, -1.0), b.Min())
+	assert.Equal(t, tuple.Point(1.0, 1.0, 1.0), b.Max())
+}
@@ -0,0 +1,149 @@
+package cylinder
+
+import (
+	"math"
+
+	mathUtil "github.com/tyz910/ray-tracer-challenge/internal/math"
+	"github.com/tyz910/ray-tracer-challenge/internal/math/tuple"
+	"github.com/tyz910/ray-tracer-challenge/internal/render/ray"
+	"github.com/tyz910/ray-tracer-challenge/internal/render/shape"
+)
+
+// Cylinder represents a cylinder of radius 1 centered on the y axis.
+// It may be truncated at the minimum and maximum y values, and optionally closed with end caps.
+type Cylinder struct {
+	shape.Base
+
+	minimum, maximum float64
+	closed           bool
+}
+
+// New creates new infinite open cylinder.
+func New() *Cylinder {
+	c := &Cylinder{
+		minimum: math.Inf(-1),
+		maximum: math.Inf(1),
+	}
+	c.Base = shape.NewBase(c)
+
+	return c
+}
+
+// Minimum returns the y value where the cylinder is truncated from below (exclusive).
+func (c *Cylinder) Minimum() float64 {
+	return c.minimum
+}
+
+// SetMinimum changes the y value where the cylinder is truncated from below.
+func (c *Cylinder) SetMinimum(minimum float64) {
+	c.minimum = minimum
+	shape.UpdateParentBounds(c.Parent())
+}
+
+// Maximum returns the y value where the cylinder is truncated from above (exclusive).
+func (c *Cylinder) Maximum() float64 {
+	return c.maximum
+}
+
+// SetMaximum changes the y value where the cylinder is truncated from above.
+func (c *Cylinder) SetMaximum(maximum float64) {
+	c.maximum = maximum
+	shape.UpdateParentBounds(c.Parent())
+}
+
+// Closed checks whether the cylinder is capped at its ends.
+func (c *Cylinder) Closed() bool {
+	return c.closed
+}
+
+// SetClosed changes whether the cylinder is capped at its ends.
+func (c *Cylinder) SetClosed(closed bool) {
+	c.closed = closed
+}
+
+// LocalIntersect appends the intersections where the ray intersects the cylinder in object space to xs.
+func (c *Cylinder) LocalIntersect(r ray.Ray, xs shape.Intersections) shape.Intersections {
+	o := r.Origin()
+	d := r.Direction()
+
+	a := d.X()*d.X() + d.Z()*d.Z()
+
+	// the ray is parallel to the y axis, so it can hit the caps only
+	if !mathUtil.Equals(a, 0.0) {
+		b := 2.0*o.X()*d.X() + 2.0*o.Z()*d.Z()
+		c2 := o.X()*o.X() + o.Z()*o.Z() - 1.0
+
+		disc := b*b - 4.0*a*c2
+
+		// the ray does not intersect the cylinder
+		if disc < 0.0 {
+			return xs
+		}
+
+		t0 := (-b - math.Sqrt(disc)) / (2.0 * a)
+		t1 := (-b + math.Sqrt(disc)) / (2.0 * a)
+		if t0 > t1 {
+			t0, t1 = t1, t0
+		}
+
+		y0 := o.Y() + t0*d.Y()
+		if c.minimum < y0 && y0 < c.maximum {
+			xs = append(xs, shape.NewIntersection(t0, c))
+		}
+
+		y1 := o.Y() + t1*d.Y()
+		if c.minimum < y1 && y1 < c.maximum {
+			xs = append(xs, shape.NewIntersection(t1, c))
+		}
+	}
+
+	return c.intersectCaps(r, xs)
+}
+
+// intersectCaps adds the intersections of the ray with the end caps of the closed cylinder.
+func (c *Cylinder) intersectCaps(r ray.Ray, xs shape.Intersections) shape.Intersections {
+	if !c.closed || mathUtil.Equals(r.Direction().Y(), 0.0) {
+		return xs
+	}
+
+	// check for an intersection with the lower end cap
+	t := (c.minimum - r.Origin().Y()) / r.Direction().Y()
+	if checkCap(r, t) {
+		xs = append(xs, shape.NewIntersection(t, c))
+	}
+
+	// check for an intersection with the upper end cap
+	t = (c.maximum - r.Origin().Y()) / r.Direction().Y()
+	if checkCap(r, t) {
+		xs = append(xs, shape.NewIntersection(t, c))
+	}
+
+	return xs
+}
+
+// LocalNormalAt returns the normal on the cylinder at the given point in object space.
+func (c *Cylinder) LocalNormalAt(p tuple.Tuple, _ *shape.Intersection) tuple.Tuple {
+	// the square of the distance from the y axis
+	dist := p.X()*p.X() + p.Z()*p.Z()
+
+	if dist < 1.0 && p.Y() >= c.maximum-mathUtil.Epsilon {
+		return tuple.Vector(0.0, 1.0, 0.0)
+	} else if dist < 1.0 && p.Y() <= c.minimum+mathUtil.Epsilon {
+		return tuple.Vector(0.0, -1.0, 0.0)
+	}
+
+	return tuple.Vector(p.X(), 0.0, p.Z())
+}
+
+// checkCap checks whether the intersection at t is within a radius of 1 from the y axis.
+func checkCap(r ray.Ray, t float64) bool {
+	x := r.Origin().X() + t*r.Direction().X()
+	z := r.Origin().Z() + t*r.Direction().Z()
+
+	return (x*x + z*z) <= 1.0
+}
+
+// Bounds returns the bounding box of the cylinder in object space.
+func (c *Cylinder) Bounds() shape.Bounds {
+	return shape.NewBounds(tuple.Point(-1.0, c.minimum, -1.0), tuple.Point(1.0, c.maximum, 1.0))
+}
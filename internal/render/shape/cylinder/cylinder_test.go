@@ -0,0 +1,248 @@
+package cylinder_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/tyz910/ray-tracer-challenge/internal/math/tuple"
+	"github.com/tyz910/ray-tracer-challenge/internal/render/ray"
+	"github.com/tyz910/ray-tracer-challenge/internal/render/shape/cylinder"
+)
+
+// A ray misses a cylinder
+func TestIntersectMiss(t *testing.T) {
+	tests := []struct {
+		Origin    tuple.Tuple
+		Direction tuple.Tuple
+	}{
+		{Origin: tuple.Point(1.0, 0.0, 0.0), Direction: tuple.Vector(0.0, 1.0, 0.0)},
+		{Origin: tuple.Point(0.0, 0.0, 0.0), Direction: tuple.Vector(0.0, 1.0, 0.0)},
+		{Origin: tuple.Point(0.0, 0.0, -5.0), Direction: tuple.Vector(1.0, 1.0, 1.0)},
+	}
+
+	for _, test := range tests {
+		t.Run("A ray misses a cylinder", func(t *testing.T) {
+			// Given
+			cyl := cylinder.New()
+			r := ray.New(test.Origin, test.Direction.Normalize())
+
+			// When
+			xs := cyl.LocalIntersect(r, nil)
+
+			// Then
+			assert.Equal(t, 0, len(xs))
+		})
+	}
+}
+
+// A ray strikes a cylinder
+func TestIntersect(t *testing.T) {
+	tests := []struct {
+		Origin    tuple.Tuple
+		Direction tuple.Tuple
+		T0, T1    float64
+	}{
+		{Origin: tuple.Point(1.0, 0.0, -5.0), Direction: tuple.Vector(0.0, 0.0, 1.0), T0: 5.0, T1: 5.0},
+		{Origin: tuple.Point(0.0, 0.0, -5.0), Direction: tuple.Vector(0.0, 0.0, 1.0), T0: 4.0, T1: 6.0},
+		{Origin: tuple.Point(0.5, 0.0, -5.0), Direction: tuple.Vector(0.1, 1.0, 1.0), T0: 6.80798, T1: 7.08872},
+	}
+
+	for _, test := range tests {
+		t.Run("A ray strikes a cylinder", func(t *testing.T) {
+			// Given
+			cyl := cylinder.New()
+			r := ray.New(test.Origin, test.Direction.Normalize())
+
+			// When
+			xs := cyl.LocalIntersect(r, nil)
+
+			// Then
+			assert.Equal(t, 2, len(xs))
+			assert.InDelta(t, test.T0, xs[0].T(), 0.00001)
+			assert.InDelta(t, test.T1, xs[1].T(), 0.00001)
+		})
+	}
+}
+
+// Normal vector on a cylinder
+func TestNormal(t *testing.T) {
+	tests := []struct {
+		Point  tuple.Tuple
+		Normal tuple.Tuple
+	}{
+		{Point: tuple.Point(1.0, 0.0, 0.0), Normal: tuple.Vector(1.0, 0.0, 0.0)},
+		{Point: tuple.Point(0.0, 5.0, -1.0), Normal: tuple.Vector(0.0, 0.0, -1.0)},
+		{Point: tuple.Point(0.0, -2.0, 1.0), Normal: tuple.Vector(0.0, 0.0, 1.0)},
+		{Point: tuple.Point(-1.0, 1.0, 0.0), Normal: tuple.Vector(-1.0, 0.0, 0.0)},
+	}
+
+	for _, test := range tests {
+		t.Run("Normal vector on a cylinder", func(t *testing.T) {
+			// Given
+			cyl := cylinder.New()
+
+			// When
+			n := cyl.LocalNormalAt(test.Point, nil)
+
+			// Then
+			assert.True(t, n.Equal(test.Normal))
+		})
+	}
+}
+
+// The default minimum and maximum for a cylinder
+func TestDefaultBounds(t *testing.T) {
+	// Given
+	cyl := cylinder.New()
+
+	// Then
+	assert.Equal(t, math.Inf(-1), cyl.Minimum())
+	assert.Equal(t, math.Inf(1), cyl.Maximum())
+}
+
+// Intersecting a constrained cylinder
+func TestIntersectConstrained(t *testing.T) {
+	tests := []struct {
+		Point     tuple.Tuple
+		Direction tuple.Tuple
+		Count     int
+	}{
+		{Point: tuple.Point(0.0, 1.5, 0.0), Direction: tuple.Vector(0.1, 1.0, 0.0), Count: 0},
+		{Point: tuple.Point(0.0, 3.0, -5.0), Direction: tuple.Vector(0.0, 0.0, 1.0), Count: 0},
+		{Point: tuple.Point(0.0, 0.0, -5.0), Direction: tuple.Vector(0.0, 0.0, 1.0), Count: 0},
+		{Point: tuple.Point(0.0, 2.0, -5.0), Direction: tuple.Vector(0.0, 0.0, 1.0), Count: 0},
+		{Point: tuple.Point(0.0, 1.0, -5.0), Direction: tuple.Vector(0.0, 0.0, 1.0), Count: 0},
+		{Point: tuple.Point(0.0, 1.5, -2.0), Direction: tuple.Vector(0.0, 0.0, 1.0), Count: 2},
+	}
+
+	for _, test := range tests {
+		t.Run("Intersecting a constrained cylinder", func(t *testing.T) {
+			// Given
+			cyl := cylinder.New()
+			cyl.SetMinimum(1.0)
+			cyl.SetMaximum(2.0)
+			r := ray.New(test.Point, test.Direction.Normalize())
+
+			// When
+			xs := cyl.LocalIntersect(r, nil)
+
+			// Then
+			assert.Equal(t, test.Count, len(xs))
+		})
+	}
+}
+
+// The default closed value for a cylinder
+func TestDefaultClosed(t *testing.T) {
+	// Given
+	cyl := cylinder.New()
+
+	// Then
+	assert.False(t, cyl.Closed())
+}
+
+// Intersecting the caps of a closed cylinder
+func TestIntersectCaps(t *testing.T) {
+	tests := []struct {
+		Point     tuple.Tuple
+		Direction tuple.Tuple
+		Count     int
+	}{
+		{Point: tuple.Point(0.0, 3.0, 0.0), Direction: tuple.Vector(0.0, -1.0, 0.0), Count: 2},
+		{Point: tuple.Point(0.0, 3.0, -2.0), Direction: tuple.Vector(0.0, -1.0, 2.0), Count: 2},
+		{Point: tuple.Point(0.0, 4.0, -2.0), Direction: tuple.Vector(0.0, -1.0, 1.0), Count: 2}, // corner case
+		{Point: tuple.Point(0.0, 0.0, -2.0), Direction: tuple.Vector(0.0, 1.0, 2.0), Count: 2},
+		{Point: tuple.Point(0.0, -1.0, -2.0), Direction: tuple.Vector(0.0, 1.0, 1.0), Count: 2}, // corner case
+	}
+
+	for _, test := range tests {
+		t.Run("Intersecting the caps of a closed cylinder", func(t *testing.T) {
+			// Given
+			cyl := cylinder.New()
+			cyl.SetMinimum(1.0)
+			cyl.SetMaximum(2.0)
+			cyl.SetClosed(true)
+			r := ray.New(test.Point, test.Direction.Normalize())
+
+			// When
+			xs := cyl.LocalIntersect(r, nil)
+
+			// Then
+			assert.Equal(t, test.Count, len(xs))
+		})
+	}
+}
+
+// The normal vector on a cylinder's end caps
+func TestNormalCaps(t *testing.T) {
+	tests := []struct {
+		Point  tuple.Tuple
+		Normal tuple.Tuple
+	}{
+		{Point: tuple.Point(0.0, 1.0, 0.0), Normal: tuple.Vector(0.0, -1.0, 0.0)},
+		{Point: tuple.Point(0.5, 1.0, 0.0), Normal: tuple.Vector(0.0, -1.0, 0.0)},
+		{Point: tuple.Point(0.0, 1.0, 0.5), Normal: tuple.Vector(0.0, -1.0, 0.0)},
+		{Point: tuple.Point(0.0, 2.0, 0.0), Normal: tuple.Vector(0.0, 1.0, 0.0)},
+		{Point: tuple.Point(0.5, 2.0, 0.0), Normal: tuple.Vector(0.0, 1.0, 0.0)},
+		{Point: tuple.Point(0.0, 2.0, 0.5), Normal: tuple.Vector(0.0, 1.0, 0.0)},
+	}
+
+	for _, test := range tests {
+		t.Run("The normal vector on a cylinder's end caps", func(t *testing.T) {
+			// Given
+			cyl := cylinder.New()
+			cyl.SetMinimum(1.0)
+			cyl.SetMaximum(2.0)
+			cyl.SetClosed(true)
+
+			// When
+			n := cyl.LocalNormalAt(test.Point, nil)
+
+			// Then
+			assert.True(t, n.Equal(test.Normal))
+		})
+	}
+}
+
+func TestBounds(t *testing.T) {
+	tests := []struct {
+		Name     string
+		Minimum  float64
+		Maximum  float64
+		Min, Max tuple.Tuple
+	}{
+		{
+			Name:    "An unbounded cylinder has a bounding box",
+			Minimum: math.Inf(-1),
+			Maximum: math.Inf(1),
+			Min:     tuple.Point(-1.0, math.Inf(-1), -1.0),
+			Max:     tuple.Point(1.0, math.Inf(1), 1.0),
+		},
+
+		{
+			Name:    "A bounded cylinder has a bounding box",
+			Minimum: -5.0,
+			Maximum: 3.0,
+			Min:     tuple.Point(-1.0, -5.0, -1.0),
+			Max:     tuple.Point(1.0, 3.0, 1.0),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			// Given
+			c := cylinder.New()
+			c.SetMinimum(test.Minimum)
+			c.SetMaximum(test.Maximum)
+
+			// When
+			b := c.Bounds()
+
+			// Then
+			assert.Equal(t, test.Min, b.Min())
+			assert.Equal(t, test.Max, b.Max())
+		})
+	}
+}
@@ -0,0 +1,160 @@
+package group
+
+import (
+	"github.com/tyz910/ray-tracer-challenge/internal/math/tuple"
+	"github.com/tyz910/ray-tracer-challenge/internal/render/ray"
+	"github.com/tyz910/ray-tracer-challenge/internal/render/shape"
+)
+
+// Group represents a collection of shapes that are transformed as a single unit.
+// The transformation of the group is applied on top of the transformations of its children.
+type Group struct {
+	shape.Base
+	children []shape.Shape
+
+	// bounds of all children in the space of the group, so rays missing them skip the children entirely
+	bounds shape.Bounds
+}
+
+// New creates new empty group.
+func New() *Group {
+	g := &Group{
+		bounds: shape.EmptyBounds(),
+	}
+	g.Base = shape.NewBase(g)
+
+	return g
+}
+
+// Children returns the shapes contained in the group.
+func (g *Group) Children() []shape.Shape {
+	return g.children
+}
+
+// AddChild adds shapes to the group.
+func (g *Group) AddChild(children ...shape.Shape) {
+	for _, child := range children {
+		child.SetParent(g)
+		g.children = append(g.children, child)
+		g.bounds = g.bounds.Merge(child.ParentSpaceBounds())
+	}
+
+	shape.UpdateParentBounds(g.Parent())
+}
+
+// Bounds returns the bounding box containing all children of the group.
+func (g *Group) Bounds() shape.Bounds {
+	return g.bounds
+}
+
+// UpdateBounds recomputes the bounding box of the group after one of the children has changed.
+func (g *Group) UpdateBounds() {
+	g.bounds = shape.EmptyBounds()
+	for _, child := range g.children {
+		g.bounds = g.bounds.Merge(child.ParentSpaceBounds())
+	}
+
+	shape.UpdateParentBounds(g.Parent())
+}
+
+// LocalIntersect appends the intersections where the ray intersects the children of the group to xs.
+// The appended intersections are sorted, while the ones already in xs are left untouched.
+func (g *Group) LocalIntersect(r ray.Ray, xs shape.Intersections) shape.Intersections {
+	if !g.bounds.Intersects(r) {
+		return xs
+	}
+
+	start := len(xs)
+	for _, child := range g.children {
+		xs = child.Intersect(r, xs)
+	}
+
+	xs[start:].Sort()
+
+	return xs
+}
+
+// LocalNormalAt panics, because normals are always computed on the children of the group.
+func (g *Group) LocalNormalAt(_ tuple.Tuple, _ *shape.Intersection) tuple.Tuple {
+	panic("local normal of a group is undefined")
+}
+
+// Includes checks whether the shape is one of the descendants of the group.
+func (g *Group) Includes(s shape.Shape) bool {
+	for _, child := range g.children {
+		if shape.Includes(child, s) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Divide builds a bounding volume hierarchy from the group. Groups with at least threshold children
+// are split in half along the longest axis of their bounds, and the children fitting entirely into
+// either half are moved into a new subgroup. The process recurses into all descendants.
+func (g *Group) Divide(threshold int) {
+	if threshold <= len(g.children) {
+		left, right := g.partitionChildren()
+
+		if len(left) > 0 {
+			g.makeSubgroup(left)
+		}
+
+		if len(right) > 0 {
+			g.makeSubgroup(right)
+		}
+	}
+
+	for _, child := range g.children {
+		shape.Divide(child, threshold)
+	}
+}
+
+// partitionChildren removes from the group the children fitting entirely into either half of the bounds
+// of its finite children, and returns them. The children overlapping both halves and the infinite children,
+// like planes, are kept in the group.
+func (g *Group) partitionChildren() (left, right []shape.Shape) {
+	// infinite children would make the halves infinite too, so that no child fits into them
+	finite := shape.EmptyBounds()
+	for _, child := range g.children {
+		if b := child.ParentSpaceBounds(); b.IsFinite() {
+			finite = finite.Merge(b)
+		}
+	}
+
+	if finite.IsEmpty() {
+		return nil, nil
+	}
+
+	leftBounds, rightBounds := finite.Split()
+
+	var rest []shape.Shape
+	for _, child := range g.children {
+		b := child.ParentSpaceBounds()
+
+		if leftBounds.ContainsBounds(b) {
+			left = append(left, child)
+		} else if rightBounds.ContainsBounds(b) {
+			right = append(right, child)
+		} else {
+			rest = append(rest, child)
+		}
+	}
+
+	// a split that moves every child to the same side makes no progress
+	if len(rest) == 0 && (len(left) == 0 || len(right) == 0) {
+		return nil, nil
+	}
+
+	g.children = rest
+
+	return
+}
+
+// makeSubgroup adds a new group containing the shapes to the group.
+func (g *Group) makeSubgroup(children []shape.Shape) {
+	sub := New()
+	sub.AddChild(children...)
+	g.AddChild(sub)
+}
@@ -0,0 +1,302 @@
+package group_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tyz910/ray-tracer-challenge/internal/math/matrix"
+	"github.com/tyz910/ray-tracer-challenge/internal/math/tuple"
+	"github.com/tyz910/ray-tracer-challenge/internal/render/ray"
+	"github.com/tyz910/ray-tracer-challenge/internal/render/shape"
+	"github.com/tyz910/ray-tracer-challenge/internal/render/shape/cube"
+	"github.com/tyz910/ray-tracer-challenge/internal/render/shape/cylinder"
+	"github.com/tyz910/ray-tracer-challenge/internal/render/shape/group"
+	"github.com/tyz910/ray-tracer-challenge/internal/render/shape/plane"
+	"github.com/tyz910/ray-tracer-challenge/internal/render/shape/sphere"
+)
+
+// Creating a new group
+func TestCreateGroup(t *testing.T) {
+	// Given
+	g := group.New()
+
+	// Then
+	assert.True(t, g.Transform().Equal(matrix.Identity4()))
+	assert.Empty(t, g.Children())
+}
+
+// Adding a child to a group
+func TestAddChild(t *testing.T) {
+	// Given
+	g := group.New()
+	s := shape.NewTestShape()
+
+	// When
+	g.AddChild(s)
+
+	// Then
+	assert.NotEmpty(t, g.Children())
+	assert.Contains(t, g.Children(), s)
+	assert.Equal(t, g, s.Parent())
+}
+
+// Intersecting a ray with an empty group
+func TestIntersectEmpty(t *testing.T) {
+	// Given
+	g := group.New()
+	r := ray.New(tuple.Point(0.0, 0.0, 0.0), tuple.Vector(0.0, 0.0, 1.0))
+
+	// When
+	xs := g.LocalIntersect(r, nil)
+
+	// Then
+	assert.Empty(t, xs)
+}
+
+// Intersecting a ray with a nonempty group
+func TestIntersectNonEmpty(t *testing.T) {
+	// Given
+	g := group.New()
+	s1 := sphere.New()
+	s2 := sphere.New()
+	s2.SetTransform(matrix.Translation(0.0, 0.0, -3.0))
+	s3 := sphere.New()
+	s3.SetTransform(matrix.Translation(5.0, 0.0, 0.0))
+	g.AddChild(s1, s2, s3)
+
+	// When
+	r := ray.New(tuple.Point(0.0, 0.0, -5.0), tuple.Vector(0.0, 0.0, 1.0))
+	xs := g.LocalIntersect(r, nil)
+
+	// Then
+	assert.Equal(t, 4, len(xs))
+	assert.Equal(t, s2, xs[0].Object())
+	assert.Equal(t, s2, xs[1].Object())
+	assert.Equal(t, s1, xs[2].Object())
+	assert.Equal(t, s1, xs[3].Object())
+}
+
+// Intersecting a transformed group
+func TestIntersectTransformed(t *testing.T) {
+	// Given
+	g := group.New()
+	g.SetTransform(matrix.Scaling(2.0, 2.0, 2.0))
+	s := sphere.New()
+	s.SetTransform(matrix.Translation(5.0, 0.0, 0.0))
+	g.AddChild(s)
+
+	// When
+	r := ray.New(tuple.Point(10.0, 0.0, -10.0), tuple.Vector(0.0, 0.0, 1.0))
+	xs := g.Intersect(r, nil)
+
+	// Then
+	assert.Equal(t, 2, len(xs))
+}
+
+// A group has a bounding box that contains its children
+func TestBounds(t *testing.T) {
+	// Given
+	s := sphere.New()
+	s.SetTransform(matrix.Translation(2.0, 5.0, -3.0).MatMul(matrix.Scaling(2.0, 2.0, 2.0)))
+	c := cylinder.New()
+	c.SetMinimum(-2.0)
+	c.SetMaximum(2.0)
+	c.SetTransform(matrix.Translation(-4.0, -1.0, 4.0).MatMul(matrix.Scaling(0.5, 1.0, 0.5)))
+	g := group.New()
+	g.AddChild(s, c)
+
+	// When
+	b := g.Bounds()
+
+	// Then
+	assert.True(t, b.Min().Equal(tuple.Point(-4.5, -3.0, -5.0)))
+	assert.True(t, b.Max().Equal(tuple.Point(4.0, 7.0, 4.5)))
+}
+
+// The bounding box of a group follows the changes of its descendants
+func TestBoundsUpdate(t *testing.T) {
+	// Given
+	s := sphere.New()
+	inner := group.New()
+	inner.AddChild(s)
+	outer := group.New()
+	outer.AddChild(inner)
+
+	// When
+	s.SetTransform(matrix.Translation(5.0, 0.0, 0.0))
+	inner.AddChild(cube.New())
+
+	// Then
+	assert.Equal(t, tuple.Point(-1.0, -1.0, -1.0), outer.Bounds().Min())
+	assert.Equal(t, tuple.Point(6.0, 1.0, 1.0), outer.Bounds().Max())
+}
+
+func TestIntersectBounds(t *testing.T) {
+	tests := []struct {
+		Name     string
+		Ray      ray.Ray
+		Expected bool
+	}{
+		{
+			Name:     "Intersecting ray+group doesn't test children if box is missed",
+			Ray:      ray.New(tuple.Point(0.0, 0.0, -5.0), tuple.Vector(0.0, 1.0, 0.0)),
+			Expected: false,
+		},
+
+		{
+			Name:     "Intersecting ray+group tests children if box is hit",
+			Ray:      ray.New(tuple.Point(0.0, 0.0, -5.0), tuple.Vector(0.0, 0.0, 1.0)),
+			Expected: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			// Given
+			child := shape.NewTestShape()
+			g := group.New()
+			g.AddChild(child)
+
+			// When
+			g.Intersect(test.Ray, nil)
+
+			// Then
+			assert.Equal(t, test.Expected, child.SavedRay() != ray.Ray{})
+		})
+	}
+}
+
+// Partitioning a group's children
+func TestDividePartition(t *testing.T) {
+	// Given
+	s1 := sphere.New()
+	s1.SetTransform(matrix.Translation(-2.0, 0.0, 0.0))
+	s2 := sphere.New()
+	s2.SetTransform(matrix.Translation(2.0, 0.0, 0.0))
+	s3 := sphere.New()
+	g := group.New()
+	g.AddChild(s1, s2, s3)
+
+	// When
+	g.Divide(3)
+
+	// Then
+	require.Equal(t, 3, len(g.Children()))
+	assert.Equal(t, s3, g.Children()[0])
+
+	left := g.Children()[1].(*group.Group)
+	assert.Equal(t, []shape.Shape{s1}, left.Children())
+
+	right := g.Children()[2].(*group.Group)
+	assert.Equal(t, []shape.Shape{s2}, right.Children())
+}
+
+// Subdividing a group partitions its children
+func TestDivide(t *testing.T) {
+	// Given
+	s1 := sphere.New()
+	s1.SetTransform(matrix.Translation(-2.0, -2.0, 0.0))
+	s2 := sphere.New()
+	s2.SetTransform(matrix.Translation(-2.0, 2.0, 0.0))
+	s3 := sphere.New()
+	s3.SetTransform(matrix.Scaling(4.0, 4.0, 4.0))
+	g := group.New()
+	g.AddChild(s1, s2, s3)
+
+	// When
+	g.Divide(1)
+
+	// Then
+	require.Equal(t, 2, len(g.Children()))
+	assert.Equal(t, s3, g.Children()[0])
+
+	subgroup := g.Children()[1].(*group.Group)
+	require.Equal(t, 2, len(subgroup.Children()))
+	assert.Equal(t, []shape.Shape{s1}, subgroup.Children()[0].(*group.Group).Children())
+	assert.Equal(t, []shape.Shape{s2}, subgroup.Children()[1].(*group.Group).Children())
+}
+
+// Subdividing a group with an infinite child
+func TestDivideInfiniteChild(t *testing.T) {
+	// Given
+	floor := plane.New()
+	g := group.New()
+	g.AddChild(floor)
+	for i := 0; i < 100; i++ {
+		s := sphere.New()
+		s.SetTransform(matrix.Translation(float64(i%10)*3.0, 1.0, float64(i/10)*3.0))
+		g.AddChild(s)
+	}
+
+	// When
+	g.Divide(4)
+
+	// Then
+	require.Equal(t, 3, len(g.Children()))
+	assert.Equal(t, floor, g.Children()[0])
+	assert.IsType(t, &group.Group{}, g.Children()[1])
+	assert.IsType(t, &group.Group{}, g.Children()[2])
+}
+
+// Subdividing a group with too few children
+func TestDivideTooFewChildren(t *testing.T) {
+	// Given
+	s1 := sphere.New()
+	s1.SetTransform(matrix.Translation(-2.0, 0.0, 0.0))
+	s2 := sphere.New()
+	s2.SetTransform(matrix.Translation(2.0, 1.0, 0.0))
+	s3 := sphere.New()
+	s3.SetTransform(matrix.Translation(2.0, -1.0, 0.0))
+	subgroup := group.New()
+	subgroup.AddChild(s1, s2, s3)
+	s4 := sphere.New()
+	g := group.New()
+	g.AddChild(subgroup, s4)
+
+	// When
+	g.Divide(3)
+
+	// Then
+	require.Equal(t, 2, len(g.Children()))
+	assert.Equal(t, subgroup, g.Children()[0])
+	assert.Equal(t, s4, g.Children()[1])
+
+	require.Equal(t, 2, len(subgroup.Children()))
+	assert.Equal(t, []shape.Shape{s1}, subgroup.Children()[0].(*group.Group).Children())
+	assert.Equal(t, []shape.Shape{s2, s3}, subgroup.Children()[1].(*group.Group).Children())
+}
+
+// Subdividing a group doesn't change the intersections
+func TestDivideIntersect(t *testing.T) {
+	// Given
+	g := group.New()
+	for x := -5.0; x <= 5.0; x++ {
+		for y := -5.0; y <= 5.0; y++ {
+			s := sphere.New()
+			s.SetTransform(matrix.Translation(x*2.0, y*2.0, 0.0).MatMul(matrix.Scaling(0.9, 0.9, 0.9)))
+			g.AddChild(s)
+		}
+	}
+
+	rays := []ray.Ray{
+		ray.New(tuple.Point(0.0, 0.0, -5.0), tuple.Vector(0.0, 0.0, 1.0)),
+		ray.New(tuple.Point(4.0, -6.0, -5.0), tuple.Vector(0.0, 0.0, 1.0)),
+		ray.New(tuple.Point(-20.0, 0.5, 0.0), tuple.Vector(1.0, 0.0, 0.0)),
+		ray.New(tuple.Point(1.0, 1.0, -5.0), tuple.Vector(0.0, 0.0, 1.0)),
+	}
+
+	var expected []shape.Intersections
+	for _, r := range rays {
+		expected = append(expected, g.Intersect(r, nil))
+	}
+
+	// When
+	g.Divide(4)
+
+	// Then
+	for i, r := range rays {
+		assert.Equal(t, expected[i], g.Intersect(r, nil))
+	}
+}
@@ -1,43 +1,76 @@
 package shape
 
+import "sort"
+
 // Intersection aggregates the t value of the intersection, and the object that was intersected.
+// For triangles it also keeps the u and v coordinates of the intersection relative to the corners of the triangle.
 type Intersection struct {
-	t   float64
-	obj Shape
+	t    float64
+	obj  Shape
+	u, v float64
 }
 
-// Intersections is a collection of intersections.
-type Intersections []*Intersection
+// Intersections is a collection of intersections. Intersections are stored by value,
+// so a collection reused across rays doesn't allocate once it has grown large enough.
+type Intersections []Intersection
 
 // NewIntersection creates new intersection.
-func NewIntersection(t float64, obj Shape) *Intersection {
-	return &Intersection{
+func NewIntersection(t float64, obj Shape) Intersection {
+	return Intersection{
+		t:   t,
+		obj: obj,
+	}
+}
+
+// NewIntersectionWithUV creates new intersection with u and v coordinates.
+func NewIntersectionWithUV(t float64, obj Shape, u, v float64) Intersection {
+	return Intersection{
 		t:   t,
 		obj: obj,
+		u:   u,
+		v:   v,
 	}
 }
 
 // T returns the t value of the intersection.
-func (i *Intersection) T() float64 {
+func (i Intersection) T() float64 {
 	return i.t
 }
 
 // Object returns the object that was intersected.
-func (i *Intersection) Object() Shape {
+func (i Intersection) Object() Shape {
 	return i.obj
 }
 
-// Hit returns the intersection which is actually visible from the ray’s origin.
+// U returns the u coordinate of the intersection.
+func (i Intersection) U() float64 {
+	return i.u
+}
+
+// V returns the v coordinate of the intersection.
+func (i Intersection) V() float64 {
+	return i.v
+}
+
+// Hit returns the intersection which is actually visible from the ray’s origin, or nil if there is none.
+// The result points into the collection.
 func (xs Intersections) Hit() (h *Intersection) {
-	for _, i := range xs {
-		if i.t < 0 {
+	for i := range xs {
+		if xs[i].t < 0 {
 			continue
 		}
 
-		if h == nil || (i.t < h.t) {
-			h = i
+		if h == nil || (xs[i].t < h.t) {
+			h = &xs[i]
 		}
 	}
 
 	return
 }
+
+// Sort sorts the intersections in ascending order of t.
+func (xs Intersections) Sort() {
+	sort.Slice(xs, func(i, j int) bool {
+		return xs[i].t < xs[j].t
+	})
+}
@@ -34,7 +34,7 @@ func TestHitPositive(t *testing.T) {
 	i := xs.Hit()
 
 	// Then
-	assert.Equal(t, i1, i)
+	assert.Equal(t, &i1, i)
 }
 
 // The hit, when some intersections have negative t
@@ -49,7 +49,7 @@ func TestHitNegative(t *testing.T) {
 	i := xs.Hit()
 
 	// Then
-	assert.Equal(t, i2, i)
+	assert.Equal(t, &i2, i)
 }
 
 // The hit, when all intersections have negative t
@@ -81,5 +81,34 @@ func TestHitLowest(t *testing.T) {
 	i := xs.Hit()
 
 	// Then
-	assert.Equal(t, i4, i)
+	assert.Equal(t, &i4, i)
+}
+
+// Sorting intersections by t
+func TestSort(t *testing.T) {
+	// Given
+	s := sphere.New()
+	i1 := shape.NewIntersection(5.0, s)
+	i2 := shape.NewIntersection(-3.0, s)
+	i3 := shape.NewIntersection(2.0, s)
+	xs := shape.Intersections{i1, i2, i3}
+
+	// When
+	xs.Sort()
+
+	// Then
+	assert.Equal(t, shape.Intersections{i2, i3, i1}, xs)
+}
+
+// An intersection can encapsulate u and v
+func TestCreateWithUV(t *testing.T) {
+	// Given
+	s := shape.NewTestShape()
+
+	// When
+	i := shape.NewIntersectionWithUV(3.5, s, 0.2, 0.4)
+
+	// Then
+	assert.Equal(t, 0.2, i.U())
+	assert.Equal(t, 0.4, i.V())
 }
@@ -0,0 +1,45 @@
+package plane
+
+import (
+	"math"
+
+	mathUtil "github.com/tyz910/ray-tracer-challenge/internal/math"
+	"github.com/tyz910/ray-tracer-challenge/internal/math/tuple"
+	"github.com/tyz910/ray-tracer-challenge/internal/render/ray"
+	"github.com/tyz910/ray-tracer-challenge/internal/render/shape"
+)
+
+// Plane represents an infinite plane extending in x and z dimensions, passing through the origin.
+type Plane struct {
+	shape.Base
+}
+
+// New creates new plane.
+func New() *Plane {
+	p := &Plane{}
+	p.Base = shape.NewBase(p)
+
+	return p
+}
+
+// LocalIntersect appends the intersection where the ray intersects the plane in object space to xs.
+func (p *Plane) LocalIntersect(r ray.Ray, xs shape.Intersections) shape.Intersections {
+	// the ray is parallel to the plane (or coplanar with it), so it never hits the plane
+	if math.Abs(r.Direction().Y()) < mathUtil.Epsilon {
+		return xs
+	}
+
+	t := -r.Origin().Y() / r.Direction().Y()
+
+	return append(xs, shape.NewIntersection(t, p))
+}
+
+// LocalNormalAt returns the normal on the plane in object space. It is the same at every point.
+func (p *Plane) LocalNormalAt(_ tuple.Tuple, _ *shape.Intersection) tuple.Tuple {
+	return tuple.Vector(0.0, 1.0, 0.0)
+}
+
+// Bounds returns the bounding box of the plane in object space. It is infinite in x and z, and flat in y.
+func (p *Plane) Bounds() shape.Bounds {
+	return shape.NewBounds(tuple.Point(math.Inf(-1), 0.0, math.Inf(-1)), tuple.Point(math.Inf(1), 0.0, math.Inf(1)))
+}
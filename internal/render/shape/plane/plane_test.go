@@ -0,0 +1,105 @@
+package plane_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/tyz910/ray-tracer-challenge/internal/math/matrix"
+	"github.com/tyz910/ray-tracer-challenge/internal/math/tuple"
+	"github.com/tyz910/ray-tracer-challenge/internal/render/ray"
+	"github.com/tyz910/ray-tracer-challenge/internal/render/shape/plane"
+)
+
+// The normal of a plane is constant everywhere
+func TestNormal(t *testing.T) {
+	// Given
+	p := plane.New()
+
+	// When
+	n1 := p.LocalNormalAt(tuple.Point(0.0, 0.0, 0.0), nil)
+	n2 := p.LocalNormalAt(tuple.Point(10.0, 0.0, -10.0), nil)
+	n3 := p.LocalNormalAt(tuple.Point(-5.0, 0.0, 150.0), nil)
+
+	// Then
+	assert.True(t, n1.Equal(tuple.Vector(0.0, 1.0, 0.0)))
+	assert.True(t, n2.Equal(tuple.Vector(0.0, 1.0, 0.0)))
+	assert.True(t, n3.Equal(tuple.Vector(0.0, 1.0, 0.0)))
+}
+
+// A ray intersects a plane
+func TestIntersect(t *testing.T) {
+	tests := []struct {
+		Name      string
+		Ray       ray.Ray
+		ExpectedT []float64
+	}{
+		{
+			Name:      "Intersect with a ray parallel to the plane",
+			Ray:       ray.New(tuple.Point(0.0, 10.0, 0.0), tuple.Vector(0.0, 0.0, 1.0)),
+			ExpectedT: []float64{},
+		},
+
+		{
+			Name:      "Intersect with a coplanar ray",
+			Ray:       ray.New(tuple.Point(0.0, 0.0, 0.0), tuple.Vector(0.0, 0.0, 1.0)),
+			ExpectedT: []float64{},
+		},
+
+		{
+			Name:      "A ray intersecting a plane from above",
+			Ray:       ray.New(tuple.Point(0.0, 1.0, 0.0), tuple.Vector(0.0, -1.0, 0.0)),
+			ExpectedT: []float64{1.0},
+		},
+
+		{
+			Name:      "A ray intersecting a plane from below",
+			Ray:       ray.New(tuple.Point(0.0, -1.0, 0.0), tuple.Vector(0.0, 1.0, 0.0)),
+			ExpectedT: []float64{1.0},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			// Given
+			p := plane.New()
+
+			// When
+			xs := p.LocalIntersect(test.Ray, nil)
+
+			// Then
+			assert.Equal(t, len(test.ExpectedT), len(xs))
+			for i, x := range test.ExpectedT {
+				assert.Equal(t, x, xs[i].T())
+				assert.Equal(t, p, xs[i].Object())
+			}
+		})
+	}
+}
+
+// The normal of a transformed plane
+func TestNormalTransformed(t *testing.T) {
+	// Given
+	p := plane.New()
+	p.SetTransform(matrix.Scaling(1.0, -1.0, 1.0))
+
+	// When
+	n := p.NormalAt(tuple.Point(3.0, 0.0, 4.0), nil)
+
+	// Then
+	assert.True(t, n.Equal(tuple.Vector(0.0, -1.0, 0.0)))
+}
+
+// A plane has a bounding box
+func TestBounds(t *testing.T) {
+	// Given
+	p := plane.New()
+
+	// When
+	b := p.Bounds()
+
+	// Then
+	assert.Equal(t, tuple.Point(math.Inf(-1), 0.0, math.Inf(-1)), b.Min())
+	assert.Equal(t, tuple.Point(math.Inf(1), 0.0, math.Inf(1)), b.Max())
+}
@@ -1,6 +1,7 @@
 package shape
 
 import (
+	"github.com/tyz910/ray-tracer-challenge/internal/math/matrix"
 	"github.com/tyz910/ray-tracer-challenge/internal/math/tuple"
 	"github.com/tyz910/ray-tracer-challenge/internal/render/material"
 	"github.com/tyz910/ray-tracer-challenge/internal/render/ray"
@@ -8,12 +9,198 @@ import (
 
 // Shape is the interface implemented by objects that can be rendered.
 type Shape interface {
-	// Intersect returns the collection of intersections where the ray intersects the object.
-	Intersect(r ray.Ray) Intersections
+	// Intersect appends the intersections where the ray intersects the object to xs and returns the extended collection.
+	// Passing a reused collection truncated to zero length avoids allocations, nil is fine otherwise.
+	Intersect(r ray.Ray, xs Intersections) Intersections
 
 	// NormalAt returns the normal on the object at the given point.
-	NormalAt(p tuple.Tuple) tuple.Tuple
+	// The hit is the intersection that produced the point, it may be nil for shapes that don't depend on it.
+	NormalAt(p tuple.Tuple, hit *Intersection) tuple.Tuple
 
 	// Material returns the surface material of the object.
 	Material() material.Material
+
+	// SetMaterial changes the surface material of the object.
+	SetMaterial(m material.Material)
+
+	// Transform returns the transformation matrix assigned to the object.
+	Transform() matrix.Mat4
+
+	// SetTransform assigns transformation matrix to the object.
+	SetTransform(m matrix.Mat4)
+
+	// Parent returns the group containing the object, or nil if the object is not part of a group.
+	Parent() Shape
+
+	// SetParent changes the group containing the object.
+	SetParent(parent Shape)
+
+	// WorldToObject converts the point from world space to object space, taking into account parent groups.
+	WorldToObject(p tuple.Tuple) tuple.Tuple
+
+	// NormalToWorld converts the normal from object space to world space, taking into account parent groups.
+	NormalToWorld(n tuple.Tuple) tuple.Tuple
+
+	// Bounds returns the bounding box of the object in object space.
+	Bounds() Bounds
+
+	// ParentSpaceBounds returns the bounding box of the object transformed into the space of its parent.
+	ParentSpaceBounds() Bounds
+}
+
+// Local is the interface implemented by primitives that describe their geometry in object space.
+type Local interface {
+	// LocalIntersect appends the intersections where the ray, already converted to object space, intersects the object
+	// to xs and returns the extended collection.
+	LocalIntersect(r ray.Ray, xs Intersections) Intersections
+
+	// LocalNormalAt returns the normal in object space on the object at the given point in object space.
+	LocalNormalAt(p tuple.Tuple, hit *Intersection) tuple.Tuple
+
+	// Bounds returns the bounding box of the object in object space.
+	Bounds() Bounds
+}
+
+// Base implements the transformation and material handling shared by all primitives.
+// The primitive embeds Base and provides only its local geometry.
+type Base struct {
+	local     Local
+	parent    Shape
+	transform matrix.Mat4
+	material  material.Material
+
+	// the inverse and the inverse transpose of the transformation are cached,
+	// because they are needed for every ray and normal computation
+	inverse          matrix.Mat4
+	inverseTranspose matrix.Mat4
+}
+
+// NewBase creates new base for the primitive with the identity transformation and the default material.
+func NewBase(local Local) Base {
+	return Base{
+		local:     local,
+		transform: matrix.Identity4(),
+		material:  material.New(),
+
+		inverse:          matrix.Identity4(),
+		inverseTranspose: matrix.Identity4(),
+	}
+}
+
+// Transform returns the transformation matrix assigned to the object.
+func (b *Base) Transform() matrix.Mat4 {
+	return b.transform
+}
+
+// SetTransform assigns transformation matrix to the object.
+func (b *Base) SetTransform(m matrix.Mat4) {
+	b.transform = m
+	b.inverse = m.Inverse()
+	b.inverseTranspose = b.inverse.Transpose()
+
+	UpdateParentBounds(b.parent)
+}
+
+// Material returns the surface material of the object.
+func (b *Base) Material() material.Material {
+	return b.material
+}
+
+// SetMaterial changes the surface material of the object.
+func (b *Base) SetMaterial(m material.Material) {
+	b.material = m
+}
+
+// Intersect converts the ray to object space and appends the intersections where it intersects the object to xs.
+func (b *Base) Intersect(r ray.Ray, xs Intersections) Intersections {
+	return b.local.LocalIntersect(r.Transform(b.inverse), xs)
+}
+
+// NormalAt converts the point to object space, computes the normal there and converts it back to world space.
+func (b *Base) NormalAt(p tuple.Tuple, hit *Intersection) tuple.Tuple {
+	localPoint := b.WorldToObject(p)
+	localNormal := b.local.LocalNormalAt(localPoint, hit)
+
+	return b.NormalToWorld(localNormal)
+}
+
+// Parent returns the group containing the object, or nil if the object is not part of a group.
+func (b *Base) Parent() Shape {
+	return b.parent
+}
+
+// SetParent changes the group containing the object.
+func (b *Base) SetParent(parent Shape) {
+	b.parent = parent
+}
+
+// WorldToObject converts the point from world space to object space, taking into account parent groups.
+func (b *Base) WorldToObject(p tuple.Tuple) tuple.Tuple {
+	if b.parent != nil {
+		p = b.parent.WorldToObject(p)
+	}
+
+	return b.inverse.TupMul(p)
+}
+
+// NormalToWorld converts the normal from object space to world space, taking into account parent groups.
+func (b *Base) NormalToWorld(n tuple.Tuple) tuple.Tuple {
+	n = b.inverseTranspose.TupMul(n).AsVector().Normalize()
+
+	if b.parent != nil {
+		n = b.parent.NormalToWorld(n)
+	}
+
+	return n
+}
+
+// ParentSpaceBounds returns the bounding box of the object transformed into the space of its parent.
+func (b *Base) ParentSpaceBounds() Bounds {
+	return b.local.Bounds().Transform(b.transform)
+}
+
+// BoundsContainer is the interface implemented by shapes that cache the bounds of their children.
+type BoundsContainer interface {
+	// UpdateBounds recomputes the cached bounds after the children have changed.
+	UpdateBounds()
+}
+
+// UpdateParentBounds notifies the parent that the bounds of one of its children have changed.
+// Shapes call it whenever a change of their geometry or transformation affects their bounds.
+func UpdateParentBounds(parent Shape) {
+	if c, ok := parent.(BoundsContainer); ok {
+		c.UpdateBounds()
+	}
+}
+
+// Container is the interface implemented by shapes composed of other shapes.
+type Container interface {
+	// Includes checks whether the shape is one of the descendants of the container.
+	Includes(s Shape) bool
+}
+
+// Includes checks whether the shape s is the shape a itself or one of its descendants.
+func Includes(a, s Shape) bool {
+	if a == s {
+		return true
+	}
+
+	if c, ok := a.(Container); ok {
+		return c.Includes(s)
+	}
+
+	return false
+}
+
+// Divider is the interface implemented by shapes that can be subdivided into a bounding volume hierarchy.
+type Divider interface {
+	// Divide splits the shape's groups with at least threshold children into nested groups where possible.
+	Divide(threshold int)
+}
+
+// Divide builds a bounding volume hierarchy from the shape if it can be subdivided.
+func Divide(s Shape, threshold int) {
+	if d, ok := s.(Divider); ok {
+		d.Divide(threshold)
+	}
 }
@@ -0,0 +1,204 @@
+package shape_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/tyz910/ray-tracer-challenge/internal/math/matrix"
+	"github.com/tyz910/ray-tracer-challenge/internal/math/tuple"
+	"github.com/tyz910/ray-tracer-challenge/internal/render/material"
+	"github.com/tyz910/ray-tracer-challenge/internal/render/ray"
+	"github.com/tyz910/ray-tracer-challenge/internal/render/shape"
+	"github.com/tyz910/ray-tracer-challenge/internal/render/shape/group"
+	"github.com/tyz910/ray-tracer-challenge/internal/render/shape/sphere"
+)
+
+// The default transformation
+func TestDefaultTransform(t *testing.T) {
+	// Given
+	s := shape.NewTestShape()
+
+	// Then
+	assert.True(t, s.Transform().Equal(matrix.Identity4()))
+}
+
+// Assigning a transformation
+func TestSetTransform(t *testing.T) {
+	// Given
+	s := shape.NewTestShape()
+
+	// When
+	s.SetTransform(matrix.Translation(2.0, 3.0, 4.0))
+
+	// Then
+	assert.True(t, s.Transform().Equal(matrix.Translation(2.0, 3.0, 4.0)))
+}
+
+// The default material
+func TestDefaultMaterial(t *testing.T) {
+	// Given
+	s := shape.NewTestShape()
+
+	// When
+	m := s.Material()
+
+	// Then
+	assert.Equal(t, material.New(), m)
+}
+
+// Assigning a material
+func TestSetMaterial(t *testing.T) {
+	// Given
+	s := shape.NewTestShape()
+	m := material.New()
+	m.SetAmbient(1.0)
+
+	// When
+	s.SetMaterial(m)
+
+	// Then
+	assert.Equal(t, m, s.Material())
+}
+
+// Intersecting a scaled shape with a ray
+func TestIntersectScaled(t *testing.T) {
+	// Given
+	r := ray.New(tuple.Point(0.0, 0.0, -5.0), tuple.Vector(0.0, 0.0, 1.0))
+	s := shape.NewTestShape()
+
+	// When
+	s.SetTransform(matrix.Scaling(2.0, 2.0, 2.0))
+	s.Intersect(r, nil)
+
+	// Then
+	assert.True(t, s.SavedRay().Origin().Equal(tuple.Point(0.0, 0.0, -2.5)))
+	assert.True(t, s.SavedRay().Direction().Equal(tuple.Vector(0.0, 0.0, 0.5)))
+}
+
+// Intersecting a translated shape with a ray
+func TestIntersectTranslated(t *testing.T) {
+	// Given
+	r := ray.New(tuple.Point(0.0, 0.0, -5.0), tuple.Vector(0.0, 0.0, 1.0))
+	s := shape.NewTestShape()
+
+	// When
+	s.SetTransform(matrix.Translation(5.0, 0.0, 0.0))
+	s.Intersect(r, nil)
+
+	// Then
+	assert.True(t, s.SavedRay().Origin().Equal(tuple.Point(-5.0, 0.0, -5.0)))
+	assert.True(t, s.SavedRay().Direction().Equal(tuple.Vector(0.0, 0.0, 1.0)))
+}
+
+// Replacing the transformation updates the cached inverse
+func TestIntersectReplacedTransform(t *testing.T) {
+	// Given
+	r := ray.New(tuple.Point(0.0, 0.0, -5.0), tuple.Vector(0.0, 0.0, 1.0))
+	s := shape.NewTestShape()
+	s.SetTransform(matrix.Translation(5.0, 0.0, 0.0))
+
+	// When
+	s.SetTransform(matrix.Scaling(2.0, 2.0, 2.0))
+	s.Intersect(r, nil)
+	n := s.NormalAt(tuple.Point(0.0, 0.0, -2.0), nil)
+
+	// Then
+	assert.True(t, s.SavedRay().Origin().Equal(tuple.Point(0.0, 0.0, -2.5)))
+	assert.True(t, s.SavedRay().Direction().Equal(tuple.Vector(0.0, 0.0, 0.5)))
+	assert.True(t, n.Equal(tuple.Vector(0.0, 0.0, -1.0)))
+}
+
+// Computing the normal on a translated shape
+func TestNormalTranslated(t *testing.T) {
+	// Given
+	s := shape.NewTestShape()
+
+	// When
+	s.SetTransform(matrix.Translation(0.0, 1.0, 0.0))
+	n := s.NormalAt(tuple.Point(0.0, 1.70711, -0.70711), nil)
+
+	// Then
+	assert.True(t, n.Equal(tuple.Vector(0.0, 0.70711, -0.70711)))
+}
+
+// Computing the normal on a transformed shape
+func TestNormalTransformed(t *testing.T) {
+	// Given
+	s := shape.NewTestShape()
+	m := matrix.Scaling(1.0, 0.5, 1.0).MatMul(matrix.RotationZ(math.Pi / 5.0))
+
+	// When
+	s.SetTransform(m)
+	n := s.NormalAt(tuple.Point(0.0, math.Sqrt(2.0)/2.0, -math.Sqrt(2.0)/2.0), nil)
+
+	// Then
+	assert.True(t, n.Equal(tuple.Vector(0.0, 0.97014, -0.24254)))
+}
+
+// A shape has a parent attribute
+func TestParent(t *testing.T) {
+	// Given
+	s := shape.NewTestShape()
+
+	// Then
+	assert.Nil(t, s.Parent())
+}
+
+// Converting a point from world to object space
+func TestWorldToObject(t *testing.T) {
+	// Given
+	g1 := group.New()
+	g1.SetTransform(matrix.RotationY(math.Pi / 2.0))
+	g2 := group.New()
+	g2.SetTransform(matrix.Scaling(2.0, 2.0, 2.0))
+	g1.AddChild(g2)
+	s := sphere.New()
+	s.SetTransform(matrix.Translation(5.0, 0.0, 0.0))
+	g2.AddChild(s)
+
+	// When
+	p := s.WorldToObject(tuple.Point(-2.0, 0.0, -10.0))
+
+	// Then
+	assert.True(t, p.Equal(tuple.Point(0.0, 0.0, -1.0)))
+}
+
+// Converting a normal from object to world space
+func TestNormalToWorld(t *testing.T) {
+	// Given
+	g1 := group.New()
+	g1.SetTransform(matrix.RotationY(math.Pi / 2.0))
+	g2 := group.New()
+	g2.SetTransform(matrix.Scaling(1.0, 2.0, 3.0))
+	g1.AddChild(g2)
+	s := sphere.New()
+	s.SetTransform(matrix.Translation(5.0, 0.0, 0.0))
+	g2.AddChild(s)
+
+	// When
+	n := s.NormalToWorld(tuple.Vector(math.Sqrt(3.0)/3.0, math.Sqrt(3.0)/3.0, math.Sqrt(3.0)/3.0))
+
+	// Then
+	assert.True(t, n.Equal(tuple.Vector(0.28571, 0.42857, -0.85714)))
+}
+
+// Finding the normal on a child object
+func TestNormalOnChild(t *testing.T) {
+	// Given
+	g1 := group.New()
+	g1.SetTransform(matrix.RotationY(math.Pi / 2.0))
+	g2 := group.New()
+	g2.SetTransform(matrix.Scaling(1.0, 2.0, 3.0))
+	g1.AddChild(g2)
+	s := sphere.New()
+	s.SetTransform(matrix.Translation(5.0, 0.0, 0.0))
+	g2.AddChild(s)
+
+	// When
+	n := s.NormalAt(tuple.Point(1.7321, 1.1547, -5.5774), nil)
+
+	// Then
+	assert.True(t, n.Equal(tuple.Vector(0.28570, 0.42854, -0.85716)))
+}
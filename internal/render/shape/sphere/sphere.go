@@ -3,61 +3,48 @@ package sphere
 import (
 	"math"
 
-	"github.com/tyz910/ray-tracer-challenge/internal/math/matrix"
 	"github.com/tyz910/ray-tracer-challenge/internal/math/tuple"
-	"github.com/tyz910/ray-tracer-challenge/internal/render/material"
 	"github.com/tyz910/ray-tracer-challenge/internal/render/ray"
 	"github.com/tyz910/ray-tracer-challenge/internal/render/shape"
 )
 
-// Sphere represents a sphere object.
+// Sphere represents a unit sphere centered at the origin.
 type Sphere struct {
-	transform matrix.Matrix
-	material  material.Material
+	shape.Base
 }
 
 // New creates new sphere.
 func New() *Sphere {
-	return &Sphere{
-		transform: matrix.Identity(),
-		material:  material.New(),
-	}
-}
+	s := &Sphere{}
+	s.Base = shape.NewBase(s)
 
-// Transform returns the transformation matrix assigned to the sphere.
-func (s *Sphere) Transform() matrix.Matrix {
-	return s.transform
+	return s
 }
 
-// SetTransform assigns transformation matrix to the sphere.
-func (s *Sphere) SetTransform(m matrix.Matrix) {
-	s.transform = m
-}
+// NewGlass creates new sphere made of a fully transparent glass.
+func NewGlass() *Sphere {
+	s := New()
 
-// Material returns the surface material of the sphere.
-func (s *Sphere) Material() material.Material {
-	return s.material
-}
+	m := s.Material()
+	m.SetTransparency(1.0)
+	m.SetRefractiveIndex(1.5)
+	s.SetMaterial(m)
 
-// SetMaterial changes the surface material of the sphere.
-func (s *Sphere) SetMaterial(m material.Material) {
-	s.material = m
+	return s
 }
 
-// Intersect returns the collection of intersections where the ray intersects the sphere.
-func (s *Sphere) Intersect(r ray.Ray) shape.Intersections {
-	rLocal := r.Transform(s.transform.Inverse())
-
+// LocalIntersect appends the intersections where the ray intersects the sphere in object space to xs.
+func (s *Sphere) LocalIntersect(r ray.Ray, xs shape.Intersections) shape.Intersections {
 	// the vector from the sphere's center, to the ray origin
-	sphereToRay := rLocal.Origin().Sub(tuple.Point(0.0, 0.0, 0.0))
+	sphereToRay := r.Origin().Sub(tuple.Point(0.0, 0.0, 0.0))
 
-	a := rLocal.Direction().Dot(rLocal.Direction())
-	b := 2.0 * rLocal.Direction().Dot(sphereToRay)
+	a := r.Direction().Dot(r.Direction())
+	b := 2.0 * r.Direction().Dot(sphereToRay)
 	c := sphereToRay.Dot(sphereToRay) - 1.0
 
 	discriminant := b*b - 4.0*a*c
 	if discriminant < 0.0 {
-		return shape.Intersections{}
+		return xs
 	}
 
 	t1 := (-b - math.Sqrt(discriminant)) / (2.0 * a)
@@ -67,18 +54,18 @@ func (s *Sphere) Intersect(r ray.Ray) shape.Intersections {
 	i2 := shape.NewIntersection(t2, s)
 
 	if t1 > t2 {
-		return shape.Intersections{i2, i1}
+		return append(xs, i2, i1)
 	}
 
-	return shape.Intersections{i1, i2}
+	return append(xs, i1, i2)
 }
 
-// NormalAt returns the normal on the sphere at the given point.
-func (s *Sphere) NormalAt(p tuple.Tuple) tuple.Tuple {
-	ti := s.transform.Inverse()
-	pLocal := ti.TupMul(p)
-	nLocal := pLocal.Sub(tuple.Point(0.0, 0.0, 0.0))
-	nWorld := ti.Transpose().TupMul(nLocal).AsVector()
+// LocalNormalAt returns the normal on the sphere at the given point in object space.
+func (s *Sphere) LocalNormalAt(p tuple.Tuple, _ *shape.Intersection) tuple.Tuple {
+	return p.Sub(tuple.Point(0.0, 0.0, 0.0))
+}
 
-	return nWorld.Normalize()
+// Bounds returns the bounding box of the sphere in object space.
+func (s *Sphere) Bounds() shape.Bounds {
+	return shape.NewBounds(tuple.Point(-1.0, -1.0, -1.0), tuple.Point(1.0, 1.0, 1.0))
 }
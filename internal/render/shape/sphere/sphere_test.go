@@ -10,6 +10,7 @@ import (
 	"github.com/tyz910/ray-tracer-challenge/internal/math/tuple"
 	"github.com/tyz910/ray-tracer-challenge/internal/render/material"
 	"github.com/tyz910/ray-tracer-challenge/internal/render/ray"
+	"github.com/tyz910/ray-tracer-challenge/internal/render/shape"
 	"github.com/tyz910/ray-tracer-challenge/internal/render/shape/sphere"
 )
 
@@ -64,7 +65,7 @@ func TestIntersect(t *testing.T) {
 			s := test.Sphere
 
 			// When
-			xs := s.Intersect(r)
+			xs := s.Intersect(r, nil)
 
 			// Then
 			assert.Equal(t, len(test.ExpectedT), len(xs))
@@ -81,7 +82,7 @@ func TestDefaultTransform(t *testing.T) {
 	s := sphere.New()
 
 	// Then
-	assert.True(t, s.Transform().Equal(matrix.Identity()))
+	assert.True(t, s.Transform().Equal(matrix.Identity4()))
 }
 
 // Changing a sphere's transformation
@@ -108,7 +109,7 @@ func TestIntersectObject(t *testing.T) {
 	s := sphere.New()
 
 	// When
-	xs := s.Intersect(r)
+	xs := s.Intersect(r, nil)
 
 	// Then
 	assert.Equal(t, 2, len(xs))
@@ -128,7 +129,7 @@ func TestIntersectScaled(t *testing.T) {
 
 	// When
 	s.SetTransform(matrix.Scaling(2.0, 2.0, 2.0))
-	xs := s.Intersect(r)
+	xs := s.Intersect(r, nil)
 
 	// Then
 	assert.Equal(t, 2, len(xs))
@@ -148,7 +149,7 @@ func TestIntersectTranslated(t *testing.T) {
 
 	// When
 	s.SetTransform(matrix.Translation(5.0, 0.0, 0.0))
-	xs := s.Intersect(r)
+	xs := s.Intersect(r, nil)
 
 	// Then
 	assert.Equal(t, 0, len(xs))
@@ -192,7 +193,7 @@ func TestNormal(t *testing.T) {
 			s := sphere.New()
 
 			// When
-			n := s.NormalAt(test.Point)
+			n := s.NormalAt(test.Point, nil)
 
 			// Then
 			assert.True(t, n.Equal(test.Normal))
@@ -206,7 +207,7 @@ func TestNormalized(t *testing.T) {
 	s := sphere.New()
 
 	// When
-	n := s.NormalAt(tuple.Point(math.Sqrt(3.0)/3.0, math.Sqrt(3.0)/3.0, math.Sqrt(3.0)/3.0))
+	n := s.NormalAt(tuple.Point(math.Sqrt(3.0)/3.0, math.Sqrt(3.0)/3.0, math.Sqrt(3.0)/3.0), nil)
 
 	// Then
 	assert.True(t, n.Equal(n.Normalize()))
@@ -219,7 +220,7 @@ func TestNormalTranslated(t *testing.T) {
 	s.SetTransform(matrix.Translation(0.0, 1.0, 0.0))
 
 	// When
-	n := s.NormalAt(tuple.Point(0.0, 1.70711, -0.70711))
+	n := s.NormalAt(tuple.Point(0.0, 1.70711, -0.70711), nil)
 
 	// Then
 	assert.True(t, n.Equal(tuple.Vector(0.0, 0.70711, -0.70711)))
@@ -233,7 +234,7 @@ func TestNormalTransformed(t *testing.T) {
 	s.SetTransform(m)
 
 	// When
-	n := s.NormalAt(tuple.Point(0.0, math.Sqrt(2.0)/2.0, -math.Sqrt(2.0)/2.0))
+	n := s.NormalAt(tuple.Point(0.0, math.Sqrt(2.0)/2.0, -math.Sqrt(2.0)/2.0), nil)
 
 	// Then
 	assert.True(t, n.Equal(tuple.Vector(0.0, 0.97014, -0.24254)))
@@ -263,3 +264,64 @@ func TestAssignMaterial(t *testing.T) {
 	// Then
 	assert.Equal(t, m, s.Material())
 }
+
+// A helper for producing a sphere with a glassy material
+func TestGlass(t *testing.T) {
+	// Given
+	s := sphere.NewGlass()
+
+	// Then
+	assert.Equal(t, matrix.Identity4(), s.Transform())
+	assert.Equal(t, 1.0, s.Material().Transparency())
+	assert.Equal(t, 1.5, s.Material().RefractiveIndex())
+}
+
+func BenchmarkIntersectMiss(b *testing.B) {
+	s := sphere.New()
+	s.SetTransform(matrix.Transform(matrix.Scaling(2.0, 2.0, 2.0), matrix.Translation(5.0, 0.0, 0.0)))
+	r := ray.New(tuple.Point(0.0, 0.0, -5.0), tuple.Vector(0.0, 0.0, 1.0))
+
+	var xs shape.Intersections
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		xs = s.Intersect(r, xs[:0])
+	}
+}
+
+func BenchmarkIntersectHit(b *testing.B) {
+	s := sphere.New()
+	s.SetTransform(matrix.Transform(matrix.Scaling(2.0, 2.0, 2.0), matrix.Translation(1.0, 0.0, 0.0)))
+	r := ray.New(tuple.Point(0.0, 0.0, -5.0), tuple.Vector(0.0, 0.0, 1.0))
+
+	var xs shape.Intersections
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		xs = s.Intersect(r, xs[:0])
+	}
+}
+
+func BenchmarkNormalAt(b *testing.B) {
+	s := sphere.New()
+	s.SetTransform(matrix.Transform(matrix.Scaling(2.0, 2.0, 2.0), matrix.Translation(1.0, 0.0, 0.0)))
+	p := tuple.Point(1.0, 2.0, 0.0)
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		s.NormalAt(p, nil)
+	}
+}
+
+// A sphere has a bounding box
+func TestBounds(t *testing.T) {
+	// Given
+	s := sphere.New()
+
+	// When
+	b := s.Bounds()
+
+	// Then
+	assert.Equal(t, tuple.Point(-1.0, -1.0, -1.0), b.Min())
+	assert.Equal(t, tuple.Point(1.0, 1.0, 1.0), b.Max())
+}
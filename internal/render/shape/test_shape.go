@@ -0,0 +1,42 @@
+package shape
+
+import (
+	"github.com/tyz910/ray-tracer-challenge/internal/math/tuple"
+	"github.com/tyz910/ray-tracer-challenge/internal/render/ray"
+)
+
+// TestShape is a primitive without geometry, used to test the behavior shared by all shapes.
+type TestShape struct {
+	Base
+	savedRay ray.Ray
+}
+
+// NewTestShape creates new test shape.
+func NewTestShape() *TestShape {
+	s := &TestShape{}
+	s.Base = NewBase(s)
+
+	return s
+}
+
+// SavedRay returns the last ray passed to LocalIntersect.
+func (s *TestShape) SavedRay() ray.Ray {
+	return s.savedRay
+}
+
+// LocalIntersect saves the ray and reports no intersections.
+func (s *TestShape) LocalIntersect(r ray.Ray, xs Intersections) Intersections {
+	s.savedRay = r
+
+	return xs
+}
+
+// LocalNormalAt returns the vector from the origin to the point.
+func (s *TestShape) LocalNormalAt(p tuple.Tuple, _ *Intersection) tuple.Tuple {
+	return p.AsVector()
+}
+
+// Bounds returns the box from (-1, -1, -1) to (1, 1, 1).
+func (s *TestShape) Bounds() Bounds {
+	return NewBounds(tuple.Point(-1.0, -1.0, -1.0), tuple.Point(1.0, 1.0, 1.0))
+}
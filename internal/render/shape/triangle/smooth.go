@@ -0,0 +1,81 @@
+package triangle
+
+import (
+	"github.com/tyz910/ray-tracer-challenge/internal/math/tuple"
+	"github.com/tyz910/ray-tracer-challenge/internal/render/ray"
+	"github.com/tyz910/ray-tracer-challenge/internal/render/shape"
+)
+
+// SmoothTriangle represents a triangle with a normal vector at each corner.
+// The normal at any point of the triangle is interpolated from the corner normals.
+type SmoothTriangle struct {
+	shape.Base
+
+	p1, p2, p3 tuple.Tuple
+	n1, n2, n3 tuple.Tuple
+	e1, e2     tuple.Tuple
+}
+
+// NewSmooth creates new smooth triangle.
+func NewSmooth(p1, p2, p3, n1, n2, n3 tuple.Tuple) *SmoothTriangle {
+	t := &SmoothTriangle{
+		p1: p1,
+		p2: p2,
+		p3: p3,
+		n1: n1,
+		n2: n2,
+		n3: n3,
+		e1: p2.Sub(p1),
+		e2: p3.Sub(p1),
+	}
+	t.Base = shape.NewBase(t)
+
+	return t
+}
+
+// P1 returns the first corner of the triangle.
+func (t *SmoothTriangle) P1() tuple.Tuple {
+	return t.p1
+}
+
+// P2 returns the second corner of the triangle.
+func (t *SmoothTriangle) P2() tuple.Tuple {
+	return t.p2
+}
+
+// P3 returns the third corner of the triangle.
+func (t *SmoothTriangle) P3() tuple.Tuple {
+	return t.p3
+}
+
+// N1 returns the normal at the first corner of the triangle.
+func (t *SmoothTriangle) N1() tuple.Tuple {
+	return t.n1
+}
+
+// N2 returns the normal at the second corner of the triangle.
+func (t *SmoothTriangle) N2() tuple.Tuple {
+	return t.n2
+}
+
+// N3 returns the normal at the third corner of the triangle.
+func (t *SmoothTriangle) N3() tuple.Tuple {
+	return t.n3
+}
+
+// LocalIntersect appends the intersection where the ray intersects the triangle in object space to xs.
+func (t *SmoothTriangle) LocalIntersect(r ray.Ray, xs shape.Intersections) shape.Intersections {
+	return intersect(t, t.p1, t.e1, t.e2, r, xs)
+}
+
+// LocalNormalAt interpolates the corner normals using the u and v coordinates of the hit.
+func (t *SmoothTriangle) LocalNormalAt(_ tuple.Tuple, hit *shape.Intersection) tuple.Tuple {
+	return t.n2.Mul(hit.U()).
+		Add(t.n3.Mul(hit.V())).
+		Add(t.n1.Mul(1.0 - hit.U() - hit.V()))
+}
+
+// Bounds returns the bounding box of the triangle in object space.
+func (t *SmoothTriangle) Bounds() shape.Bounds {
+	return shape.EmptyBounds().AddPoint(t.p1).AddPoint(t.p2).AddPoint(t.p3)
+}
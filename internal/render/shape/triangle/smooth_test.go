@@ -0,0 +1,84 @@
+package triangle_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/tyz910/ray-tracer-challenge/internal/math/tuple"
+	"github.com/tyz910/ray-tracer-challenge/internal/render/ray"
+	"github.com/tyz910/ray-tracer-challenge/internal/render/shape"
+	"github.com/tyz910/ray-tracer-challenge/internal/render/shape/triangle"
+)
+
+func smoothTriangle() *triangle.SmoothTriangle {
+	return triangle.NewSmooth(
+		tuple.Point(0.0, 1.0, 0.0),
+		tuple.Point(-1.0, 0.0, 0.0),
+		tuple.Point(1.0, 0.0, 0.0),
+		tuple.Vector(0.0, 1.0, 0.0),
+		tuple.Vector(-1.0, 0.0, 0.0),
+		tuple.Vector(1.0, 0.0, 0.0),
+	)
+}
+
+// Constructing a smooth triangle
+func TestCreateSmoothTriangle(t *testing.T) {
+	// Given
+	tri := smoothTriangle()
+
+	// Then
+	assert.True(t, tri.P1().Equal(tuple.Point(0.0, 1.0, 0.0)))
+	assert.True(t, tri.P2().Equal(tuple.Point(-1.0, 0.0, 0.0)))
+	assert.True(t, tri.P3().Equal(tuple.Point(1.0, 0.0, 0.0)))
+	assert.True(t, tri.N1().Equal(tuple.Vector(0.0, 1.0, 0.0)))
+	assert.True(t, tri.N2().Equal(tuple.Vector(-1.0, 0.0, 0.0)))
+	assert.True(t, tri.N3().Equal(tuple.Vector(1.0, 0.0, 0.0)))
+}
+
+// An intersection with a smooth triangle stores u/v
+func TestSmoothIntersectUV(t *testing.T) {
+	// Given
+	tri := smoothTriangle()
+	r := ray.New(tuple.Point(-0.2, 0.3, -2.0), tuple.Vector(0.0, 0.0, 1.0))
+
+	// When
+	xs := tri.LocalIntersect(r, nil)
+
+	// Then
+	assert.InDelta(t, 0.45, xs[0].U(), 0.00001)
+	assert.InDelta(t, 0.25, xs[0].V(), 0.00001)
+}
+
+// A smooth triangle uses u/v to interpolate the normal
+func TestSmoothNormal(t *testing.T) {
+	// Given
+	tri := smoothTriangle()
+	i := shape.NewIntersectionWithUV(1.0, tri, 0.45, 0.25)
+
+	// When
+	n := tri.NormalAt(tuple.Point(0.0, 0.0, 0.0), &i)
+
+	// Then
+	assert.True(t, n.Equal(tuple.Vector(-0.5547, 0.83205, 0.0)))
+}
+
+// A smooth triangle has a bounding box
+func TestSmoothBounds(t *testing.T) {
+	// Given
+	tri := triangle.NewSmooth(
+		tuple.Point(-3.0, 7.0, 2.0),
+		tuple.Point(6.0, 2.0, -4.0),
+		tuple.Point(2.0, -1.0, -1.0),
+		tuple.Vector(0.0, 1.0, 0.0),
+		tuple.Vector(-1.0, 0.0, 0.0),
+		tuple.Vector(1.0, 0.0, 0.0),
+	)
+
+	// When
+	b := tri.Bounds()
+
+	// Then
+	assert.Equal(t, tuple.Point(-3.0, -1.0, -4.0), b.Min())
+	assert.Equal(t, tuple.Point(6.0, 7.0, 2.0), b.Max())
+}
@@ -0,0 +1,111 @@
+package triangle
+
+import (
+	"math"
+
+	mathUtil "github.com/tyz910/ray-tracer-challenge/internal/math"
+	"github.com/tyz910/ray-tracer-challenge/internal/math/tuple"
+	"github.com/tyz910/ray-tracer-challenge/internal/render/ray"
+	"github.com/tyz910/ray-tracer-challenge/internal/render/shape"
+)
+
+// Triangle represents a flat triangle defined by its three corner points.
+type Triangle struct {
+	shape.Base
+
+	p1, p2, p3 tuple.Tuple
+	e1, e2     tuple.Tuple
+	normal     tuple.Tuple
+}
+
+// New creates new triangle.
+func New(p1, p2, p3 tuple.Tuple) *Triangle {
+	t := &Triangle{
+		p1: p1,
+		p2: p2,
+		p3: p3,
+		e1: p2.Sub(p1),
+		e2: p3.Sub(p1),
+	}
+	t.normal = t.e2.Cross(t.e1).Normalize()
+	t.Base = shape.NewBase(t)
+
+	return t
+}
+
+// P1 returns the first corner of the triangle.
+func (t *Triangle) P1() tuple.Tuple {
+	return t.p1
+}
+
+// P2 returns the second corner of the triangle.
+func (t *Triangle) P2() tuple.Tuple {
+	return t.p2
+}
+
+// P3 returns the third corner of the triangle.
+func (t *Triangle) P3() tuple.Tuple {
+	return t.p3
+}
+
+// E1 returns the edge vector from the first corner to the second one.
+func (t *Triangle) E1() tuple.Tuple {
+	return t.e1
+}
+
+// E2 returns the edge vector from the first corner to the third one.
+func (t *Triangle) E2() tuple.Tuple {
+	return t.e2
+}
+
+// Normal returns the normal of the triangle. It is the same at every point.
+func (t *Triangle) Normal() tuple.Tuple {
+	return t.normal
+}
+
+// LocalIntersect appends the intersection where the ray intersects the triangle in object space to xs.
+func (t *Triangle) LocalIntersect(r ray.Ray, xs shape.Intersections) shape.Intersections {
+	return intersect(t, t.p1, t.e1, t.e2, r, xs)
+}
+
+// LocalNormalAt returns the normal of the triangle in object space.
+func (t *Triangle) LocalNormalAt(_ tuple.Tuple, _ *shape.Intersection) tuple.Tuple {
+	return t.normal
+}
+
+// intersect implements the Möller–Trumbore ray-triangle intersection algorithm.
+// The appended intersection keeps the u and v coordinates of the hit relative to the triangle corners.
+func intersect(obj shape.Shape, p1, e1, e2 tuple.Tuple, r ray.Ray, xs shape.Intersections) shape.Intersections {
+	dirCrossE2 := r.Direction().Cross(e2)
+	det := e1.Dot(dirCrossE2)
+
+	// the ray is parallel to the triangle
+	if math.Abs(det) < mathUtil.Epsilon {
+		return xs
+	}
+
+	f := 1.0 / det
+
+	// the ray misses the p1-p3 edge
+	p1ToOrigin := r.Origin().Sub(p1)
+	u := f * p1ToOrigin.Dot(dirCrossE2)
+	if u < 0.0 || u > 1.0 {
+		return xs
+	}
+
+	// the ray misses the p1-p2 or p2-p3 edge
+	originCrossE1 := p1ToOrigin.Cross(e1)
+	v := f * r.Direction().Dot(originCrossE1)
+	if v < 0.0 || (u+v) > 1.0 {
+		return xs
+	}
+
+	t := f * e2.Dot(originCrossE1)
+
+	return append(xs, shape.NewIntersectionWithUV(t, obj, u, v))
+}
+
+// Bounds returns the bounding box of the triangle in object space.
+func (t *Triangle) Bounds() shape.Bounds {
+	return shape.EmptyBounds().AddPoint(t.p1).AddPoint(t.p2).AddPoint(t.p3)
+}
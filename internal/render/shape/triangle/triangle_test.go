@@ -0,0 +1,115 @@
+package triangle_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/tyz910/ray-tracer-challenge/internal/math/tuple"
+	"github.com/tyz910/ray-tracer-challenge/internal/render/ray"
+	"github.com/tyz910/ray-tracer-challenge/internal/render/shape/triangle"
+)
+
+// Constructing a triangle
+func TestCreateTriangle(t *testing.T) {
+	// Given
+	p1 := tuple.Point(0.0, 1.0, 0.0)
+	p2 := tuple.Point(-1.0, 0.0, 0.0)
+	p3 := tuple.Point(1.0, 0.0, 0.0)
+	tri := triangle.New(p1, p2, p3)
+
+	// Then
+	assert.True(t, tri.P1().Equal(p1))
+	assert.True(t, tri.P2().Equal(p2))
+	assert.True(t, tri.P3().Equal(p3))
+	assert.True(t, tri.E1().Equal(tuple.Vector(-1.0, -1.0, 0.0)))
+	assert.True(t, tri.E2().Equal(tuple.Vector(1.0, -1.0, 0.0)))
+	assert.True(t, tri.Normal().Equal(tuple.Vector(0.0, 0.0, -1.0)))
+}
+
+// Intersecting a ray with a triangle
+func TestIntersect(t *testing.T) {
+	tests := []struct {
+		Name      string
+		Ray       ray.Ray
+		ExpectedT []float64
+	}{
+		{
+			Name:      "Intersecting a ray parallel to the triangle",
+			Ray:       ray.New(tuple.Point(0.0, -1.0, -2.0), tuple.Vector(0.0, 1.0, 0.0)),
+			ExpectedT: []float64{},
+		},
+
+		{
+			Name:      "A ray misses the p1-p3 edge",
+			Ray:       ray.New(tuple.Point(1.0, 1.0, -2.0), tuple.Vector(0.0, 0.0, 1.0)),
+			ExpectedT: []float64{},
+		},
+
+		{
+			Name:      "A ray misses the p1-p2 edge",
+			Ray:       ray.New(tuple.Point(-1.0, 1.0, -2.0), tuple.Vector(0.0, 0.0, 1.0)),
+			ExpectedT: []float64{},
+		},
+
+		{
+			Name:      "A ray misses the p2-p3 edge",
+			Ray:       ray.New(tuple.Point(0.0, -1.0, -2.0), tuple.Vector(0.0, 0.0, 1.0)),
+			ExpectedT: []float64{},
+		},
+
+		{
+			Name:      "A ray strikes a triangle",
+			Ray:       ray.New(tuple.Point(0.0, 0.5, -2.0), tuple.Vector(0.0, 0.0, 1.0)),
+			ExpectedT: []float64{2.0},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			// Given
+			tri := triangle.New(tuple.Point(0.0, 1.0, 0.0), tuple.Point(-1.0, 0.0, 0.0), tuple.Point(1.0, 0.0, 0.0))
+
+			// When
+			xs := tri.LocalIntersect(test.Ray, nil)
+
+			// Then
+			assert.Equal(t, len(test.ExpectedT), len(xs))
+			for i, x := range test.ExpectedT {
+				assert.Equal(t, x, xs[i].T())
+			}
+		})
+	}
+}
+
+// Finding the normal on a triangle
+func TestNormal(t *testing.T) {
+	// Given
+	tri := triangle.New(tuple.Point(0.0, 1.0, 0.0), tuple.Point(-1.0, 0.0, 0.0), tuple.Point(1.0, 0.0, 0.0))
+
+	// When
+	n1 := tri.LocalNormalAt(tuple.Point(0.0, 0.5, 0.0), nil)
+	n2 := tri.LocalNormalAt(tuple.Point(-0.5, 0.75, 0.0), nil)
+	n3 := tri.LocalNormalAt(tuple.Point(0.5, 0.25, 0.0), nil)
+
+	// Then
+	assert.True(t, n1.Equal(tri.Normal()))
+	assert.True(t, n2.Equal(tri.Normal()))
+	assert.True(t, n3.Equal(tri.Normal()))
+}
+
+// A triangle has a bounding box
+func TestBounds(t *testing.T) {
+	// Given
+	p1 := tuple.Point(-3.0, 7.0, 2.0)
+	p2 := tuple.Point(6.0, 2.0, -4.0)
+	p3 := tuple.Point(2.0, -1.0, -1.0)
+	tri := triangle.New(p1, p2, p3)
+
+	// When
+	b := tri.Bounds()
+
+	// Then
+	assert.Equal(t, tuple.Point(-3.0, -1.0, -4.0), b.Min())
+	assert.Equal(t, tuple.Point(6.0, 7.0, 2.0), b.Max())
+}
@@ -0,0 +1,192 @@
+package render
+
+import (
+	"math"
+
+	"github.com/tyz910/ray-tracer-challenge/internal/canvas/color"
+	"github.com/tyz910/ray-tracer-challenge/internal/math/matrix"
+	"github.com/tyz910/ray-tracer-challenge/internal/math/tuple"
+	"github.com/tyz910/ray-tracer-challenge/internal/render/light"
+	"github.com/tyz910/ray-tracer-challenge/internal/render/material"
+	"github.com/tyz910/ray-tracer-challenge/internal/render/ray"
+	"github.com/tyz910/ray-tracer-challenge/internal/render/shape"
+	"github.com/tyz910/ray-tracer-challenge/internal/render/shape/sphere"
+)
+
+// DefaultMaxDepth is the default limit of recursive rays spawned from a single camera ray.
+const DefaultMaxDepth = 5
+
+// World is a collection of all objects in a scene and the light sources illuminating them.
+type World struct {
+	objects  []shape.Shape
+	lights   []light.Light
+	maxDepth int
+}
+
+// NewWorld creates new empty world.
+func NewWorld() *World {
+	return &World{
+		maxDepth: DefaultMaxDepth,
+	}
+}
+
+// DefaultWorld creates new world with two concentric spheres and a single point light.
+func DefaultWorld() *World {
+	w := NewWorld()
+	w.AddLight(light.New(tuple.Point(-10.0, 10.0, -10.0), color.White()))
+
+	s1 := sphere.New()
+	m := material.New()
+	m.SetColor(color.New(0.8, 1.0, 0.6))
+	m.SetDiffuse(0.7)
+	m.SetSpecular(0.2)
+	s1.SetMaterial(m)
+
+	s2 := sphere.New()
+	s2.SetTransform(matrix.Scaling(0.5, 0.5, 0.5))
+
+	w.AddObject(s1, s2)
+
+	return w
+}
+
+// Objects returns the objects contained in the world.
+func (w *World) Objects() []shape.Shape {
+	return w.objects
+}
+
+// AddObject adds objects to the world.
+func (w *World) AddObject(objects ...shape.Shape) {
+	w.objects = append(w.objects, objects...)
+}
+
+// Lights returns the light sources of the world.
+func (w *World) Lights() []light.Light {
+	return w.lights
+}
+
+// AddLight adds light sources to the world.
+func (w *World) AddLight(lights ...light.Light) {
+	w.lights = append(w.lights, lights...)
+}
+
+// SetLight replaces all light sources of the world with the given one.
+func (w *World) SetLight(l light.Light) {
+	w.lights = []light.Light{l}
+}
+
+// MaxDepth returns the limit of recursive rays, such as reflected rays, spawned from a single camera ray.
+func (w *World) MaxDepth() int {
+	return w.maxDepth
+}
+
+// SetMaxDepth changes the limit of recursive rays spawned from a single camera ray.
+func (w *World) SetMaxDepth(maxDepth int) {
+	w.maxDepth = maxDepth
+}
+
+// IntersectWorld returns the sorted collection of intersections where the ray intersects the objects of the world.
+func (w *World) IntersectWorld(r ray.Ray) shape.Intersections {
+	var xs shape.Intersections
+	for _, obj := range w.objects {
+		xs = obj.Intersect(r, xs)
+	}
+
+	xs.Sort()
+
+	return xs
+}
+
+// ShadeHit returns the color at the intersection encapsulated by comps.
+// The remaining argument limits how many more recursive rays may be spawned.
+func (w *World) ShadeHit(comps Computations, remaining int) color.Color {
+	surface := color.Black()
+	for _, l := range w.lights {
+		shadowed := w.IsShadowed(comps.OverPoint(), l)
+		surface = surface.Add(Lighting(comps.Object().Material(), comps.Object(), l, comps.OverPoint(), comps.EyeVec(), comps.NormalVec(), shadowed))
+	}
+
+	reflected := w.ReflectedColor(comps, remaining)
+	refracted := w.RefractedColor(comps, remaining)
+
+	// blend reflection and refraction using the Fresnel effect
+	m := comps.Object().Material()
+	if m.Reflective() > 0.0 && m.Transparency() > 0.0 {
+		reflectance := comps.Schlick()
+
+		return surface.Add(reflected.Mul(reflectance)).Add(refracted.Mul(1.0 - reflectance))
+	}
+
+	return surface.Add(reflected).Add(refracted)
+}
+
+// ReflectedColor returns the color seen in the reflection at the intersection encapsulated by comps,
+// or black if the material is not reflective or there are no remaining recursive rays.
+func (w *World) ReflectedColor(comps Computations, remaining int) color.Color {
+	reflective := comps.Object().Material().Reflective()
+	if remaining <= 0 || reflective == 0.0 {
+		return color.Black()
+	}
+
+	r := ray.New(comps.OverPoint(), comps.ReflectVec())
+	c := w.ColorAt(r, remaining-1)
+
+	return c.Mul(reflective)
+}
+
+// RefractedColor returns the color seen through the surface at the intersection encapsulated by comps,
+// or black if the material is opaque, there are no remaining recursive rays or total internal reflection occurs.
+func (w *World) RefractedColor(comps Computations, remaining int) color.Color {
+	transparency := comps.Object().Material().Transparency()
+	if remaining <= 0 || transparency == 0.0 {
+		return color.Black()
+	}
+
+	// find the ratio of the first index of refraction to the second (inverted from the definition of Snell's law)
+	nRatio := comps.N1() / comps.N2()
+
+	// cos(theta_i) is the same as the dot product of the two vectors
+	cosI := comps.EyeVec().Dot(comps.NormalVec())
+
+	// find sin(theta_t)^2 via trigonometric identity
+	sin2t := nRatio * nRatio * (1.0 - cosI*cosI)
+	if sin2t > 1.0 {
+		// total internal reflection
+		return color.Black()
+	}
+
+	// find cos(theta_t) via trigonometric identity
+	cosT := math.Sqrt(1.0 - sin2t)
+
+	// compute the direction of the refracted ray
+	direction := comps.NormalVec().Mul(nRatio*cosI - cosT).Sub(comps.EyeVec().Mul(nRatio))
+
+	r := ray.New(comps.UnderPoint(), direction)
+	c := w.ColorAt(r, remaining-1)
+
+	return c.Mul(transparency)
+}
+
+// IsShadowed checks whether the point is in shadow, i.e. any object lies between the point and the light source.
+func (w *World) IsShadowed(p tuple.Tuple, l light.Light) bool {
+	v := l.Position().Sub(p)
+	distance := v.Magnitude()
+	direction := v.Normalize()
+
+	r := ray.New(p, direction)
+	h := w.IntersectWorld(r).Hit()
+
+	return h != nil && h.T() < distance
+}
+
+// ColorAt returns the color at the intersection of the ray with the world, or black if there is no such intersection.
+// The remaining argument limits how many more recursive rays may be spawned.
+func (w *World) ColorAt(r ray.Ray, remaining int) color.Color {
+	xs := w.IntersectWorld(r)
+	h := xs.Hit()
+	if h == nil {
+		return color.Black()
+	}
+
+	return w.ShadeHit(PrepareComputations(h, r, xs...), remaining)
+}
@@ -0,0 +1,472 @@
+package render_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/tyz910/ray-tracer-challenge/internal/canvas/color"
+	"github.com/tyz910/ray-tracer-challenge/internal/math/matrix"
+	"github.com/tyz910/ray-tracer-challenge/internal/math/tuple"
+	"github.com/tyz910/ray-tracer-challenge/internal/render"
+	"github.com/tyz910/ray-tracer-challenge/internal/render/light"
+	"github.com/tyz910/ray-tracer-challenge/internal/render/material"
+	"github.com/tyz910/ray-tracer-challenge/internal/render/pattern"
+	"github.com/tyz910/ray-tracer-challenge/internal/render/ray"
+	"github.com/tyz910/ray-tracer-challenge/internal/render/shape"
+	"github.com/tyz910/ray-tracer-challenge/internal/render/shape/plane"
+	"github.com/tyz910/ray-tracer-challenge/internal/render/shape/sphere"
+)
+
+// Creating a world
+func TestCreateWorld(t *testing.T) {
+	// Given
+	w := render.NewWorld()
+
+	// Then
+	assert.Empty(t, w.Objects())
+	assert.Empty(t, w.Lights())
+	assert.Equal(t, render.DefaultMaxDepth, w.MaxDepth())
+}
+
+// The default world
+func TestDefaultWorld(t *testing.T) {
+	// Given
+	l := light.New(tuple.Point(-10.0, 10.0, -10.0), color.White())
+
+	m := material.New()
+	m.SetColor(color.New(0.8, 1.0, 0.6))
+	m.SetDiffuse(0.7)
+	m.SetSpecular(0.2)
+
+	// When
+	w := render.DefaultWorld()
+
+	// Then
+	assert.Equal(t, []light.Light{l}, w.Lights())
+	assert.Equal(t, 2, len(w.Objects()))
+
+	s1 := w.Objects()[0].(*sphere.Sphere)
+	assert.Equal(t, m, s1.Material())
+	assert.True(t, s1.Transform().Equal(matrix.Identity4()))
+
+	s2 := w.Objects()[1].(*sphere.Sphere)
+	assert.Equal(t, material.New(), s2.Material())
+	assert.True(t, s2.Transform().Equal(matrix.Scaling(0.5, 0.5, 0.5)))
+}
+
+// Intersect a world with a ray
+func TestIntersectWorld(t *testing.T) {
+	// Given
+	w := render.DefaultWorld()
+	r := ray.New(tuple.Point(0.0, 0.0, -5.0), tuple.Vector(0.0, 0.0, 1.0))
+
+	// When
+	xs := w.IntersectWorld(r)
+
+	// Then
+	assert.Equal(t, 4, len(xs))
+	assert.Equal(t, 4.0, xs[0].T())
+	assert.Equal(t, 4.5, xs[1].T())
+	assert.Equal(t, 5.5, xs[2].T())
+	assert.Equal(t, 6.0, xs[3].T())
+}
+
+// Shading an intersection
+func TestShadeHit(t *testing.T) {
+	// Given
+	w := render.DefaultWorld()
+	r := ray.New(tuple.Point(0.0, 0.0, -5.0), tuple.Vector(0.0, 0.0, 1.0))
+	s := w.Objects()[0]
+	i := shape.NewIntersection(4.0, s)
+
+	// When
+	comps := render.PrepareComputations(&i, r)
+	c := w.ShadeHit(comps, w.MaxDepth())
+
+	// Then
+	assert.True(t, c.Equal(color.New(0.38066, 0.47583, 0.2855)))
+}
+
+// Shading an intersection from the inside
+func TestShadeHitInside(t *testing.T) {
+	// Given
+	w := render.DefaultWorld()
+	w.SetLight(light.New(tuple.Point(0.0, 0.25, 0.0), color.White()))
+	r := ray.New(tuple.Point(0.0, 0.0, 0.0), tuple.Vector(0.0, 0.0, 1.0))
+	s := w.Objects()[1]
+	i := shape.NewIntersection(0.5, s)
+
+	// When
+	comps := render.PrepareComputations(&i, r)
+	c := w.ShadeHit(comps, w.MaxDepth())
+
+	// Then
+	assert.True(t, c.Equal(color.New(0.90498, 0.90498, 0.90498)))
+}
+
+// shade_hit() is given an intersection in shadow
+func TestShadeHitShadow(t *testing.T) {
+	// Given
+	w := render.NewWorld()
+	w.AddLight(light.New(tuple.Point(0.0, 0.0, -10.0), color.White()))
+	s1 := sphere.New()
+	s2 := sphere.New()
+	s2.SetTransform(matrix.Translation(0.0, 0.0, 10.0))
+	w.AddObject(s1, s2)
+	r := ray.New(tuple.Point(0.0, 0.0, 5.0), tuple.Vector(0.0, 0.0, 1.0))
+	i := shape.NewIntersection(4.0, s2)
+
+	// When
+	comps := render.PrepareComputations(&i, r)
+	c := w.ShadeHit(comps, w.MaxDepth())
+
+	// Then
+	assert.True(t, c.Equal(color.New(0.1, 0.1, 0.1)))
+}
+
+// The color when a ray misses
+func TestColorAtMiss(t *testing.T) {
+	// Given
+	w := render.DefaultWorld()
+	r := ray.New(tuple.Point(0.0, 0.0, -5.0), tuple.Vector(0.0, 1.0, 0.0))
+
+	// When
+	c := w.ColorAt(r, w.MaxDepth())
+
+	// Then
+	assert.True(t, c.Equal(color.Black()))
+}
+
+// The color when a ray hits
+func TestColorAtHit(t *testing.T) {
+	// Given
+	w := render.DefaultWorld()
+	r := ray.New(tuple.Point(0.0, 0.0, -5.0), tuple.Vector(0.0, 0.0, 1.0))
+
+	// When
+	c := w.ColorAt(r, w.MaxDepth())
+
+	// Then
+	assert.True(t, c.Equal(color.New(0.38066, 0.47583, 0.2855)))
+}
+
+// The color with an intersection behind the ray
+func TestColorAtBehind(t *testing.T) {
+	// Given
+	w := render.DefaultWorld()
+
+	outer := w.Objects()[0]
+	m := outer.Material()
+	m.SetAmbient(1.0)
+	outer.SetMaterial(m)
+
+	inner := w.Objects()[1]
+	m = inner.Material()
+	m.SetAmbient(1.0)
+	inner.SetMaterial(m)
+
+	r := ray.New(tuple.Point(0.0, 0.0, 0.75), tuple.Vector(0.0, 0.0, -1.0))
+
+	// When
+	c := w.ColorAt(r, w.MaxDepth())
+
+	// Then
+	assert.True(t, c.Equal(inner.Material().Color()))
+}
+
+// Testing for shadows
+func TestIsShadowed(t *testing.T) {
+	tests := []struct {
+		Name     string
+		Point    tuple.Tuple
+		Shadowed bool
+	}{
+		{
+			Name:     "There is no shadow when nothing is collinear with point and light",
+			Point:    tuple.Point(0.0, 10.0, 0.0),
+			Shadowed: false,
+		},
+
+		{
+			Name:     "The shadow when an object is between the point and the light",
+			Point:    tuple.Point(10.0, -10.0, 10.0),
+			Shadowed: true,
+		},
+
+		{
+			Name:     "There is no shadow when an object is behind the light",
+			Point:    tuple.Point(-20.0, 20.0, -20.0),
+			Shadowed: false,
+		},
+
+		{
+			Name:     "There is no shadow when an object is behind the point",
+			Point:    tuple.Point(-2.0, 2.0, -2.0),
+			Shadowed: false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			// Given
+			w := render.DefaultWorld()
+
+			// Then
+			assert.Equal(t, test.Shadowed, w.IsShadowed(test.Point, w.Lights()[0]))
+		})
+	}
+}
+
+// The reflected color for a nonreflective material
+func TestReflectedColorNonReflective(t *testing.T) {
+	// Given
+	w := render.DefaultWorld()
+	r := ray.New(tuple.Point(0.0, 0.0, 0.0), tuple.Vector(0.0, 0.0, 1.0))
+	s := w.Objects()[1]
+	m := s.Material()
+	m.SetAmbient(1.0)
+	s.SetMaterial(m)
+	i := shape.NewIntersection(1.0, s)
+
+	// When
+	comps := render.PrepareComputations(&i, r)
+	c := w.ReflectedColor(comps, w.MaxDepth())
+
+	// Then
+	assert.Equal(t, color.Black(), c)
+}
+
+func TestReflective(t *testing.T) {
+	tests := []struct {
+		Name      string
+		Remaining int
+		Shade     bool
+		Color     color.Color
+	}{
+		{
+			Name:      "The reflected color for a reflective material",
+			Remaining: render.DefaultMaxDepth,
+			Color:     color.New(0.19032, 0.2379, 0.14274),
+		},
+
+		{
+			Name:      "shade_hit() with a reflective material",
+			Remaining: render.DefaultMaxDepth,
+			Shade:     true,
+			Color:     color.New(0.87677, 0.92436, 0.82918),
+		},
+
+		{
+			Name:      "The reflected color at the maximum recursive depth",
+			Remaining: 0,
+			Color:     color.Black(),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			// Given
+			w := render.DefaultWorld()
+			s := plane.New()
+			m := material.New()
+			m.SetReflective(0.5)
+			s.SetMaterial(m)
+			s.SetTransform(matrix.Translation(0.0, -1.0, 0.0))
+			w.AddObject(s)
+			r := ray.New(tuple.Point(0.0, 0.0, -3.0), tuple.Vector(0.0, -math.Sqrt2/2.0, math.Sqrt2/2.0))
+			i := shape.NewIntersection(math.Sqrt2, s)
+
+			// When
+			comps := render.PrepareComputations(&i, r)
+			var c color.Color
+			if test.Shade {
+				c = w.ShadeHit(comps, test.Remaining)
+			} else {
+				c = w.ReflectedColor(comps, test.Remaining)
+			}
+
+			// Then
+			assert.InDelta(t, test.Color.Red(), c.Red(), 0.0001)
+			assert.InDelta(t, test.Color.Green(), c.Green(), 0.0001)
+			assert.InDelta(t, test.Color.Blue(), c.Blue(), 0.0001)
+		})
+	}
+}
+
+// color_at() with mutually reflective surfaces
+func TestColorAtMutuallyReflective(t *testing.T) {
+	// Given
+	w := render.NewWorld()
+	w.AddLight(light.New(tuple.Point(0.0, 0.0, 0.0), color.White()))
+	m := material.New()
+	m.SetReflective(1.0)
+
+	lower := plane.New()
+	lower.SetMaterial(m)
+	lower.SetTransform(matrix.Translation(0.0, -1.0, 0.0))
+
+	upper := plane.New()
+	upper.SetMaterial(m)
+	upper.SetTransform(matrix.Translation(0.0, 1.0, 0.0))
+
+	w.AddObject(lower, upper)
+	r := ray.New(tuple.Point(0.0, 0.0, 0.0), tuple.Vector(0.0, 1.0, 0.0))
+
+	// Then
+	assert.NotPanics(t, func() {
+		w.ColorAt(r, w.MaxDepth())
+	})
+}
+
+func TestRefractedColor(t *testing.T) {
+	tests := []struct {
+		Name         string
+		Transparency float64
+		Ray          ray.Ray
+		T            []float64
+		Hit          int
+		Remaining    int
+	}{
+		{
+			Name:         "The refracted color with an opaque surface",
+			Transparency: 0.0,
+			Ray:          ray.New(tuple.Point(0.0, 0.0, -5.0), tuple.Vector(0.0, 0.0, 1.0)),
+			T:            []float64{4.0, 6.0},
+			Hit:          0,
+			Remaining:    render.DefaultMaxDepth,
+		},
+
+		{
+			Name:         "The refracted color at the maximum recursive depth",
+			Transparency: 1.0,
+			Ray:          ray.New(tuple.Point(0.0, 0.0, -5.0), tuple.Vector(0.0, 0.0, 1.0)),
+			T:            []float64{4.0, 6.0},
+			Hit:          0,
+			Remaining:    0,
+		},
+
+		{
+			Name:         "The refracted color under total internal reflection",
+			Transparency: 1.0,
+			Ray:          ray.New(tuple.Point(0.0, 0.0, math.Sqrt2/2.0), tuple.Vector(0.0, 1.0, 0.0)),
+			T:            []float64{-math.Sqrt2 / 2.0, math.Sqrt2 / 2.0},
+			Hit:          1,
+			Remaining:    render.DefaultMaxDepth,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			// Given
+			w := render.DefaultWorld()
+			s := w.Objects()[0]
+			m := s.Material()
+			m.SetTransparency(test.Transparency)
+			m.SetRefractiveIndex(1.5)
+			s.SetMaterial(m)
+			xs := shape.Intersections{}
+			for _, tv := range test.T {
+				xs = append(xs, shape.NewIntersection(tv, s))
+			}
+
+			// When
+			comps := render.PrepareComputations(&xs[test.Hit], test.Ray, xs...)
+			c := w.RefractedColor(comps, test.Remaining)
+
+			// Then
+			assert.Equal(t, color.Black(), c)
+		})
+	}
+}
+
+// The refracted color with a refracted ray
+func TestRefractedColorRefractedRay(t *testing.T) {
+	// Given
+	w := render.DefaultWorld()
+
+	a := w.Objects()[0]
+	m := a.Material()
+	m.SetAmbient(1.0)
+	m.SetPattern(pattern.NewTestPattern())
+	a.SetMaterial(m)
+
+	b := w.Objects()[1]
+	m = b.Material()
+	m.SetTransparency(1.0)
+	m.SetRefractiveIndex(1.5)
+	b.SetMaterial(m)
+
+	r := ray.New(tuple.Point(0.0, 0.0, 0.1), tuple.Vector(0.0, 1.0, 0.0))
+	xs := shape.Intersections{
+		shape.NewIntersection(-0.9899, a),
+		shape.NewIntersection(-0.4899, b),
+		shape.NewIntersection(0.4899, b),
+		shape.NewIntersection(0.9899, a),
+	}
+
+	// When
+	comps := render.PrepareComputations(&xs[2], r, xs...)
+	c := w.RefractedColor(comps, render.DefaultMaxDepth)
+
+	// Then
+	assert.InDelta(t, 0.0, c.Red(), 0.0001)
+	assert.InDelta(t, 0.99888, c.Green(), 0.0001)
+	assert.InDelta(t, 0.04725, c.Blue(), 0.0001)
+}
+
+func TestShadeHitTransparent(t *testing.T) {
+	tests := []struct {
+		Name       string
+		Reflective float64
+		Color      color.Color
+	}{
+		{
+			Name:       "shade_hit() with a transparent material",
+			Reflective: 0.0,
+			Color:      color.New(0.93642, 0.68642, 0.68642),
+		},
+
+		{
+			Name:       "shade_hit() with a reflective, transparent material",
+			Reflective: 0.5,
+			Color:      color.New(0.93391, 0.69643, 0.69243),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			// Given
+			w := render.DefaultWorld()
+
+			floor := plane.New()
+			floor.SetTransform(matrix.Translation(0.0, -1.0, 0.0))
+			m := material.New()
+			m.SetReflective(test.Reflective)
+			m.SetTransparency(0.5)
+			m.SetRefractiveIndex(1.5)
+			floor.SetMaterial(m)
+
+			ball := sphere.New()
+			ball.SetTransform(matrix.Translation(0.0, -3.5, -0.5))
+			m = material.New()
+			m.SetColor(color.New(1.0, 0.0, 0.0))
+			m.SetAmbient(0.5)
+			ball.SetMaterial(m)
+
+			w.AddObject(floor, ball)
+			r := ray.New(tuple.Point(0.0, 0.0, -3.0), tuple.Vector(0.0, -math.Sqrt2/2.0, math.Sqrt2/2.0))
+			xs := shape.Intersections{shape.NewIntersection(math.Sqrt2, floor)}
+
+			// When
+			comps := render.PrepareComputations(&xs[0], r, xs...)
+			c := w.ShadeHit(comps, render.DefaultMaxDepth)
+
+			// Then
+			assert.InDelta(t, test.Color.Red(), c.Red(), 0.0001)
+			assert.InDelta(t, test.Color.Green(), c.Green(), 0.0001)
+			assert.InDelta(t, test.Color.Blue(), c.Blue(), 0.0001)
+		})
+	}
+}